@@ -0,0 +1,50 @@
+package sdk
+
+import "time"
+
+// GetObjectURLs generates presigned download URLs for many objects at once,
+// keyed by filename. All URLs share a single X-Mos-Expires value, so a page of
+// links expires together, and the keyed HMAC is set up only once.
+//
+// Example:
+//
+//	urls := client.GetObjectURLs([]string{"a.jpg", "b.jpg"}, time.Hour)
+//	fmt.Println(urls["a.jpg"])
+func (c *Client) GetObjectURLs(filenames []string, expiresIn time.Duration) map[string]string {
+	paths := make([]string, len(filenames))
+	for i, filename := range filenames {
+		paths[i] = c.objectPath(filename)
+	}
+	byPath := c.PresignBatch("GET", paths, expiresIn)
+
+	urls := make(map[string]string, len(filenames))
+	for i, filename := range filenames {
+		urls[filename] = byPath[paths[i]]
+	}
+	return urls
+}
+
+// PresignBatch presigns many API paths for the same method, keyed by path.
+// The expiry is computed once, so every URL carries an identical X-Mos-Expires
+// value regardless of how long the batch takes to sign. URLs are empty if the
+// client's SignatureVersion or ExpiresFormat is not supported.
+//
+// Example:
+//
+//	urls := client.PresignBatch("DELETE", []string{path1, path2}, 15*time.Minute)
+func (c *Client) PresignBatch(method string, paths []string, expiresIn time.Duration) map[string]string {
+	expires := time.Now().Add(expiresIn).Unix()
+	h := c.newHMAC()
+	basePath := c.basePath()
+
+	urls := make(map[string]string, len(paths))
+	for _, path := range paths {
+		signature, err := c.signWith(h, method, basePath+path, expires, nil)
+		if err != nil {
+			urls[path] = ""
+			continue
+		}
+		urls[path] = c.buildPresignedURL(path, expires, signature, nil)
+	}
+	return urls
+}
@@ -0,0 +1,60 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGetObjectURLs(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	filenames := []string{"a.jpg", "b.jpg", "nested/c.jpg"}
+	urls := client.GetObjectURLs(filenames, time.Hour)
+
+	if len(urls) != len(filenames) {
+		t.Fatalf("expected %d URLs, got %d", len(filenames), len(urls))
+	}
+
+	var sharedExpires string
+	for _, filename := range filenames {
+		parsed, err := url.Parse(urls[filename])
+		if err != nil {
+			t.Fatalf("%s: generated URL should be valid: %v", filename, err)
+		}
+		query := parsed.Query()
+
+		if sharedExpires == "" {
+			sharedExpires = query.Get("X-Mos-Expires")
+		} else if query.Get("X-Mos-Expires") != sharedExpires {
+			t.Errorf("%s: all URLs should share one expiry", filename)
+		}
+
+		var expires int64
+		fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+		expected := client.GenerateSignature("GET", parsed.EscapedPath(), expires)
+		if query.Get("X-Mos-Signature") != expected {
+			t.Errorf("%s: signature mismatch with reused HMAC", filename)
+		}
+	}
+}
+
+func TestPresignBatch(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	paths := []string{"/api/v1/one", "/api/v1/two"}
+	urls := client.PresignBatch("DELETE", paths, time.Hour)
+
+	first, _ := url.Parse(urls[paths[0]])
+	second, _ := url.Parse(urls[paths[1]])
+	if first.Query().Get("X-Mos-Expires") != second.Query().Get("X-Mos-Expires") {
+		t.Error("all URLs should share one expiry")
+	}
+
+	var expires int64
+	fmt.Sscanf(second.Query().Get("X-Mos-Expires"), "%d", &expires)
+	if second.Query().Get("X-Mos-Signature") != client.GenerateSignature("DELETE", paths[1], expires) {
+		t.Error("signature should cover method and path")
+	}
+}
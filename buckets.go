@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Bucket describes a bucket within the client's project.
+type Bucket struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ProjectID string `json:"project_id"`
+	Public    bool   `json:"public"`
+	CreatedAt string `json:"created_at"`
+}
+
+// BucketOptions provides options for bucket creation.
+type BucketOptions struct {
+	Public bool // Make objects readable without authentication (default: private)
+}
+
+// createBucketRequest is the request body of CreateBucket.
+type createBucketRequest struct {
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+}
+
+// bucketsPath returns the API path of the project's bucket collection.
+func (c *Client) bucketsPath() string {
+	return fmt.Sprintf("%s/projects/%s/buckets", c.apiRoot(), url.PathEscape(c.ProjectID))
+}
+
+// CreateBucket creates a new bucket in the client's project. The client's own
+// BucketName is not changed; use a separate client to work with the new bucket.
+//
+// Example:
+//
+//	err := client.CreateBucket("tenant-42", &sdk.BucketOptions{Public: false})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) CreateBucket(name string, opts *BucketOptions) error {
+	if opts == nil {
+		opts = &BucketOptions{}
+	}
+	body := createBucketRequest{Name: name, Public: opts.Public}
+	return c.doJSON("POST", c.bucketsPath(), nil, body, nil, "create bucket")
+}
+
+// DeleteBucket deletes a bucket from the client's project.
+//
+// Example:
+//
+//	if err := client.DeleteBucket("tenant-42"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) DeleteBucket(name string) error {
+	return c.doJSON("DELETE", c.bucketsPath()+"/"+url.PathEscape(name), nil, nil, nil, "delete bucket")
+}
+
+// ListBuckets returns all buckets in the client's project.
+//
+// Example:
+//
+//	buckets, err := client.ListBuckets()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, b := range buckets {
+//	    fmt.Println(b.Name, b.Public)
+//	}
+func (c *Client) ListBuckets() ([]Bucket, error) {
+	var result struct {
+		Buckets []Bucket `json:"buckets"`
+	}
+	if err := c.doJSON("GET", c.bucketsPath(), nil, nil, &result, "list buckets"); err != nil {
+		return nil, err
+	}
+	return result.Buckets, nil
+}
@@ -0,0 +1,60 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBucketManagement(t *testing.T) {
+	bucketsPath := fmt.Sprintf("/api/v1/projects/%s/buckets", testProjectID)
+	var buckets []Bucket
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("X-Mos-Signature") == "" {
+			t.Error("bucket requests should be presigned")
+		}
+		switch {
+		case r.Method == "POST" && r.URL.Path == bucketsPath:
+			var body createBucketRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			buckets = append(buckets, Bucket{Name: body.Name, Public: body.Public, ProjectID: testProjectID})
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "GET" && r.URL.Path == bucketsPath:
+			json.NewEncoder(w).Encode(map[string][]Bucket{"buckets": buckets})
+		case r.Method == "DELETE" && r.URL.Path == bucketsPath+"/tenant-1":
+			buckets = buckets[1:]
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	if err := client.CreateBucket("tenant-1", &BucketOptions{Public: true}); err != nil {
+		t.Fatalf("create should succeed: %v", err)
+	}
+	if err := client.CreateBucket("tenant-2", nil); err != nil {
+		t.Fatalf("create should succeed: %v", err)
+	}
+
+	list, err := client.ListBuckets()
+	if err != nil {
+		t.Fatalf("list should succeed: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "tenant-1" || !list[0].Public || list[1].Public {
+		t.Errorf("unexpected buckets: %+v", list)
+	}
+
+	if err := client.DeleteBucket("tenant-1"); err != nil {
+		t.Fatalf("delete should succeed: %v", err)
+	}
+	if len(buckets) != 1 {
+		t.Errorf("expected 1 bucket left, got %d", len(buckets))
+	}
+}
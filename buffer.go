@@ -0,0 +1,44 @@
+package sdk
+
+import (
+	"io"
+	"sync"
+)
+
+// NewBufferPool returns a pool of size-byte copy buffers for Client.BufferPool.
+// Sharing one pool across downloads avoids allocating a buffer per transfer,
+// which reduces GC pressure under many concurrent downloads.
+//
+// Example:
+//
+//	client.BufferPool = sdk.NewBufferPool(64 << 10)
+func NewBufferPool(size int) *sync.Pool {
+	return &sync.Pool{
+		New: func() interface{} {
+			buf := make([]byte, size)
+			return &buf
+		},
+	}
+}
+
+// copyBuffer copies src to dst with a buffer from the client's BufferPool, or
+// like io.Copy when no usable pool is configured.
+func (c *Client) copyBuffer(dst io.Writer, src io.Reader) (int64, error) {
+	if c.BufferPool == nil {
+		return io.Copy(dst, src)
+	}
+	buf, ok := c.BufferPool.Get().(*[]byte)
+	if !ok || len(*buf) == 0 {
+		return io.Copy(dst, src)
+	}
+	defer c.BufferPool.Put(buf)
+
+	// Hide io.ReaderFrom (implemented by *os.File), which would otherwise
+	// bypass the pooled buffer and allocate its own
+	return io.CopyBuffer(writerOnly{dst}, src, *buf)
+}
+
+// writerOnly exposes only the Write method of an io.Writer.
+type writerOnly struct {
+	io.Writer
+}
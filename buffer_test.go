@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDownload_BufferPool(t *testing.T) {
+	content := strings.Repeat("0123456789", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	allocated := 0
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.BufferPool = &sync.Pool{New: func() interface{} {
+		mu.Lock()
+		allocated++
+		mu.Unlock()
+		buf := make([]byte, 512)
+		return &buf
+	}}
+
+	var out bytes.Buffer
+	result, err := client.DownloadToWriter("data.txt", &out, nil)
+	if err != nil {
+		t.Fatalf("DownloadToWriter failed: %v", err)
+	}
+	if out.String() != content || result.Size != int64(len(content)) {
+		t.Errorf("writer received %d bytes, want %d", out.Len(), len(content))
+	}
+
+	localPath := filepath.Join(t.TempDir(), "data.txt")
+	if _, err := client.DownloadWithOptions("data.txt", localPath, nil); err != nil {
+		t.Fatalf("DownloadWithOptions failed: %v", err)
+	}
+	if data, _ := os.ReadFile(localPath); string(data) != content {
+		t.Errorf("file has %d bytes, want %d", len(data), len(content))
+	}
+
+	if allocated == 0 {
+		t.Error("downloads should take their copy buffer from the pool")
+	}
+}
@@ -4,43 +4,240 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/tls"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
-	"mime/multipart"
+	"math/rand"
+	"mime"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 // FileResponse represents the response returned by the API after uploading a file.
 // The URL field contains the actual public URL with the server-generated UUID filename.
 type FileResponse struct {
-	ID            string                 `json:"id"`
-	Name          string                 `json:"name"`
-	OriginalName  string                 `json:"original_name"`
-	Size          int64                  `json:"size"`
-	SizeFormatted string                 `json:"size_formatted"`
-	MimeType      string                 `json:"mime_type"`
-	BucketID      string                 `json:"bucket_id"`
-	URL           string                 `json:"url"` // This is the actual URL with server-generated UUID
-	Metadata      map[string]interface{} `json:"metadata"`
-	CreatedAt     string                 `json:"created_at"`
-	UpdatedAt     string                 `json:"updated_at"`
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	OriginalName    string                 `json:"original_name"`
+	Size            int64                  `json:"size"`
+	SizeFormatted   string                 `json:"size_formatted"`
+	MimeType        string                 `json:"mime_type"`
+	BucketID        string                 `json:"bucket_id"`
+	URL             string                 `json:"url"` // This is the actual URL with server-generated UUID
+	ETag            string                 `json:"etag,omitempty"`
+	EncryptionKeyID string                 `json:"encryption_key_id,omitempty"` // Server-side encryption key, empty if unencrypted
+	Metadata        map[string]interface{} `json:"metadata"`
+	CreatedAt       string                 `json:"created_at"`
+	UpdatedAt       string                 `json:"updated_at"`
+	RequestID       string                 `json:"-"` // Server request ID (X-Request-ID), for support escalations
+	Skipped         bool                   `json:"-"` // Upload skipped by SkipIfExists; fields describe the existing object
+
+	// Stats describes the transfer for responses returned by Upload,
+	// UploadBytes and UploadPut. It is nil for responses from other calls.
+	Stats *UploadStats `json:"-"`
+}
+
+// UploadStats reports how long an upload took and how fast it went.
+type UploadStats struct {
+	Bytes      int64         // File content bytes sent, before compression and multipart framing
+	Duration   time.Duration // Time from sending the request to parsing the response
+	Throughput float64       // Bytes per second
+
+	// Chunked reports whether the body was sent with chunked transfer
+	// encoding because its length was unknown. It is false for uploads sent
+	// with a Content-Length, including UploadReader with SpoolToDisk.
+	Chunked bool
+}
+
+// newUploadStats computes UploadStats for n bytes sent since start.
+func newUploadStats(n int64, start time.Time) *UploadStats {
+	stats := &UploadStats{Bytes: n, Duration: time.Since(start)}
+	if seconds := stats.Duration.Seconds(); seconds > 0 {
+		stats.Throughput = float64(n) / seconds
+	}
+	return stats
+}
+
+// ObjectKey returns the server-generated filename of the object, which is what
+// GetObjectURL, Download and Delete expect. It uses Name and falls back to the
+// last path segment of URL when Name is empty. It returns "" if neither is set.
+//
+// Example:
+//
+//	resp, _ := client.Upload("photo.jpg", nil)
+//	url := client.GetObjectURL(resp.ObjectKey(), time.Hour)
+func (r *FileResponse) ObjectKey() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	if r.URL == "" {
+		return ""
+	}
+
+	key, _ := lastPathSegment(r.URL)
+	return key
+}
+
+// serverKeyPattern matches server-generated object keys: a UUID followed by an
+// optional file extension.
+var serverKeyPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}(\.[0-9A-Za-z]+)*$`)
+
+// ParseObjectKeyFromURL extracts the server-generated object key (UUID filename)
+// from an object URL such as FileResponse.URL, a public URL or a presigned URL.
+// It returns an error if the last path segment is not a UUID with an optional
+// extension.
+//
+// Example:
+//
+//	key, err := sdk.ParseObjectKeyFromURL(resp.URL)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// key: "8aabd7f7-1dbf-4ea4-8918-db66069746e7.jpg"
+func ParseObjectKeyFromURL(rawurl string) (string, error) {
+	key, err := lastPathSegment(rawurl)
+	if err != nil {
+		return "", err
+	}
+	if !serverKeyPattern.MatchString(key) {
+		return "", fmt.Errorf("URL %q does not end in a server-generated object key", rawurl)
+	}
+	return key, nil
+}
+
+// lastPathSegment returns the unescaped last segment of rawurl's path.
+func lastPathSegment(rawurl string) (string, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", rawurl, err)
+	}
+	key := path.Base(u.Path)
+	if key == "/" || key == "." {
+		return "", fmt.Errorf("URL %q has no object key", rawurl)
+	}
+	return key, nil
 }
 
 // Client represents a Miphira Object Storage API client.
+//
+// The optional transport fields must be set before the first request is made;
+// the underlying HTTP client is built once and reused afterwards.
 type Client struct {
 	BaseURL    string
 	ProjectID  string
 	BucketName string
 	AccessKey  string
 	SecretKey  string
+
+	// PreviousSecretKey is the secret being rotated out. VerifyPresignedURL
+	// accepts signatures made with it as well as with SecretKey, so URLs minted
+	// before a rotation keep working for the overlap window. It is never used
+	// to sign.
+	PreviousSecretKey string
+
+	// HTTPClient, if set, is used for all requests and the transport fields
+	// below are ignored.
+	HTTPClient *http.Client
+
+	// TLSConfig customizes certificate verification, e.g. to trust an internal
+	// CA via RootCAs. Nil uses the system trust store.
+	TLSConfig *tls.Config
+
+	// InsecureSkipVerify disables TLS certificate verification entirely.
+	// Only use this in development with self-signed certificates.
+	InsecureSkipVerify bool
+
+	// DialTimeout, TLSHandshakeTimeout and ResponseHeaderTimeout bound the
+	// phases of a request before the body is transferred: connecting, the
+	// TLS handshake and waiting for the response headers. Unlike an overall
+	// timeout they do not cut off long transfers (default: 0, the values of
+	// http.DefaultTransport).
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+
+	// DisableRedirects stops requests from following 3xx responses, e.g. from
+	// a CDN to an edge location, which are followed by default. A redirect
+	// then fails the operation with an APIError matching ErrRedirect whose
+	// Location holds the target.
+	DisableRedirects bool
+
+	// SignatureVersion selects the signing algorithm (default: SignatureV1).
+	SignatureVersion SignatureVersion
+
+	// ExpiresFormat selects the encoding of X-Mos-Expires (default: ExpiresUnix).
+	ExpiresFormat ExpiresFormat
+
+	// ProxyURL routes all requests through the given proxy (http://, https://
+	// or socks5://). When empty, HTTP_PROXY/HTTPS_PROXY/NO_PROXY from the
+	// environment are honored.
+	ProxyURL string
+
+	// PublicURLTemplate overrides the URL returned by GetPublicObjectURL, e.g.
+	// to serve public objects through a CDN. The placeholders {projectID},
+	// {bucket} and {filename} are replaced with the path-escaped values:
+	// "https://cdn.example.com/{bucket}/{filename}".
+	PublicURLTemplate string
+
+	// DisablePublicURLs forbids unauthenticated public URLs for buckets that
+	// are not public. GetPublicObjectURL then returns "", PublicObjectURL and
+	// downloads with UsePublicURL fail with ErrPublicURLsDisabled.
+	DisablePublicURLs bool
+
+	// APIVersion selects the API version used in request paths, e.g. "v2"
+	// for /api/v2/... (default: "v1").
+	APIVersion string
+
+	// MaxRetries is the number of times a failed request is retried (default:
+	// 0, no retries). Rate limiting (429) and 503 responses are retried for all
+	// requests; network errors and other 5xx responses only for idempotent ones.
+	MaxRetries int
+
+	// Backoff chooses the delay between retries (default: ExponentialBackoff
+	// with full jitter). A Retry-After header from the server takes precedence.
+	Backoff Backoff
+
+	// Metrics receives one observation per API operation with its duration,
+	// size and outcome (default: NopMetrics).
+	Metrics MetricsCollector
+
+	// BufferPool, if set, supplies the copy buffers for downloads instead of
+	// allocating one per transfer. It must return *[]byte values; see
+	// NewBufferPool.
+	BufferPool *sync.Pool
+
+	// MaxURLExpiry caps the lifetime of URLs from GetObjectURLSeconds
+	// (default: 7 days).
+	MaxURLExpiry time.Duration
+
+	// URLCache, if set, makes GetObjectURL reuse previously generated URLs
+	// until they near expiry (default: nil, every call signs a new URL).
+	URLCache *URLCache
+
+	// ExtraHeaders are added to every API request, e.g. a tenant header
+	// required by a gateway. A header the SDK already sets on a request (such
+	// as Content-Type on uploads) is never overwritten; the request fails with
+	// ErrInvalidConfig instead.
+	ExtraHeaders map[string]string
+
+	httpOnce sync.Once
+	client   *http.Client
 }
 
 // NewClient creates a new Object Storage client with all required configuration.
@@ -54,9 +251,12 @@ type Client struct {
 //	    "MOS_YourAccessKey12345678",              // Access Key
 //	    "your-secret-key-here",                   // Secret Key
 //	)
+//
+// Trailing slashes are trimmed from baseURL, so "https://storage.miphira.com/"
+// and "https://storage.miphira.com" behave the same.
 func NewClient(baseURL, projectID, bucketName, accessKey, secretKey string) *Client {
 	return &Client{
-		BaseURL:    baseURL,
+		BaseURL:    strings.TrimRight(baseURL, "/"),
 		ProjectID:  projectID,
 		BucketName: bucketName,
 		AccessKey:  accessKey,
@@ -64,14 +264,138 @@ func NewClient(baseURL, projectID, bucketName, accessKey, secretKey string) *Cli
 	}
 }
 
+// accessKeyPrefix is the prefix every Miphira access key starts with.
+const accessKeyPrefix = "MOS_"
+
+// Validate checks that the client configuration is usable: all fields are set,
+// BaseURL is an absolute http(s) URL and AccessKey has the expected MOS_ prefix.
+// Call it at startup to catch misconfiguration before the first request fails.
+//
+// Example:
+//
+//	client := sdk.NewClient(baseURL, projectID, bucket, accessKey, secretKey)
+//	if err := client.Validate(); err != nil {
+//	    log.Fatalf("invalid storage config: %v", err)
+//	}
+func (c *Client) Validate() error {
+	required := []struct {
+		name, value string
+	}{
+		{"base URL", c.BaseURL},
+		{"project ID", c.ProjectID},
+		{"bucket name", c.BucketName},
+		{"access key", c.AccessKey},
+		{"secret key", c.SecretKey},
+	}
+	for _, field := range required {
+		if strings.TrimSpace(field.value) == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidConfig, field.name)
+		}
+	}
+
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return fmt.Errorf("%w: base URL %q is not a valid URL: %v", ErrInvalidConfig, c.BaseURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: base URL %q must be an absolute http or https URL", ErrInvalidConfig, c.BaseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%w: base URL %q must not contain a query or fragment", ErrInvalidConfig, c.BaseURL)
+	}
+
+	if !strings.HasPrefix(c.AccessKey, accessKeyPrefix) {
+		return fmt.Errorf("%w: access key must start with %q", ErrInvalidConfig, accessKeyPrefix)
+	}
+
+	if err := c.checkSigning(); err != nil {
+		return err
+	}
+
+	if c.ProxyURL != "" {
+		if _, err := parseProxyURL(c.ProxyURL); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	}
+
+	if strings.ContainsAny(c.APIVersion, "/?#") {
+		return fmt.Errorf("%w: API version %q must be a single path segment", ErrInvalidConfig, c.APIVersion)
+	}
+
+	if c.PublicURLTemplate != "" && !strings.Contains(c.PublicURLTemplate, "{filename}") {
+		return fmt.Errorf("%w: public URL template must contain {filename}", ErrInvalidConfig)
+	}
+
+	return nil
+}
+
 // GenerateSignature creates an HMAC-SHA256 signature for the given parameters.
 func (c *Client) GenerateSignature(method, path string, expires int64) string {
-	stringToSign := fmt.Sprintf("%s\n%s\n%d", method, path, expires)
+	return c.GenerateSignatureWithParams(method, path, expires, nil)
+}
+
+// GenerateSignatureWithParams creates an HMAC-SHA256 signature that also covers
+// the given query parameters. The parameters are canonicalized (sorted by key and
+// URL-encoded) and appended to the string-to-sign as a fourth line:
+//
+//	METHOD\nPATH\nEXPIRES\nk1=v1&k2=v2
+//
+// The X-Mos-AccessKey, X-Mos-Expires, X-Mos-Signature and X-Mos-SignatureVersion
+// parameters are never signed. When no other parameters are given the result is identical to
+// GenerateSignature.
+//
+// The string-to-sign depends on the client's SignatureVersion. An unsupported
+// version or ExpiresFormat yields an empty signature rather than a signature
+// over an empty string; Validate reports it up front.
+func (c *Client) GenerateSignatureWithParams(method, path string, expires int64, params url.Values) string {
+	signature, _ := c.signWith(c.newHMAC(), method, path, expires, params)
+	return signature
+}
 
-	h := hmac.New(sha256.New, []byte(c.SecretKey))
+// newHMAC returns an HMAC-SHA256 keyed with the client's secret key.
+func (c *Client) newHMAC() hash.Hash {
+	return hmac.New(sha256.New, []byte(c.SecretKey))
+}
+
+// signWith computes a signature using h, which is reset first so a single
+// keyed HMAC can be reused across many signatures. An unsupported
+// SignatureVersion or ExpiresFormat fails with ErrInvalidConfig.
+func (c *Client) signWith(h hash.Hash, method, path string, expires int64, params url.Values) (string, error) {
+	stringToSign, err := c.stringToSign(method, path, expires, canonicalQuery(params))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	h.Reset()
 	h.Write([]byte(stringToSign))
 
-	return base64.URLEncoding.EncodeToString(h.Sum(nil))
+	return base64.URLEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// checkSigning reports ErrInvalidConfig if the client cannot sign requests
+// because its SignatureVersion or ExpiresFormat is not supported.
+func (c *Client) checkSigning() error {
+	if _, err := c.stringToSign("GET", "/", 0, ""); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	return nil
+}
+
+// canonicalQuery returns the sorted, URL-encoded form of params with the
+// authentication parameters removed.
+func canonicalQuery(params url.Values) string {
+	if len(params) == 0 {
+		return ""
+	}
+	signed := url.Values{}
+	for k, v := range params {
+		switch k {
+		case "X-Mos-AccessKey", "X-Mos-Expires", "X-Mos-Signature", "X-Mos-SignatureVersion":
+			continue
+		}
+		signed[k] = v
+	}
+	return signed.Encode()
 }
 
 // GeneratePresignedURL creates a presigned URL for the specified HTTP method and path.
@@ -81,28 +405,319 @@ func (c *Client) GenerateSignature(method, path string, expires int64) string {
 //   - path: API path (e.g., /api/v1/projects/{projectId}/buckets/{bucketName}/objects/{filename})
 //   - expiresIn: Duration until the URL expires
 //
-// Returns a fully-formed presigned URL with authentication parameters.
+// Returns a fully-formed presigned URL with authentication parameters, or an
+// empty string if the client's SignatureVersion or ExpiresFormat is not
+// supported (see Validate).
+//
+// If BaseURL carries a path prefix (e.g. https://gateway.example.com/storage),
+// the signature covers the full request path including the prefix, so the
+// signed path always matches the path the server receives.
 func (c *Client) GeneratePresignedURL(method, path string, expiresIn time.Duration) string {
+	return c.GeneratePresignedURLWithParams(method, path, nil, expiresIn)
+}
+
+// GeneratePresignedURLWithParams creates a presigned URL that carries additional
+// query parameters. The parameters are included in the signature, so the server
+// rejects the URL if any of them is altered or removed.
+//
+// Example:
+//
+//	params := url.Values{"response-content-disposition": {"inline"}}
+//	u := client.GeneratePresignedURLWithParams("GET", path, params, time.Hour)
+func (c *Client) GeneratePresignedURLWithParams(method, path string, params url.Values, expiresIn time.Duration) string {
+	presignedURL, _ := c.presignURL(method, path, params, expiresIn)
+	return presignedURL
+}
+
+// presignURL implements GeneratePresignedURLWithParams, reporting an
+// unusable signing configuration as an error.
+func (c *Client) presignURL(method, path string, params url.Values, expiresIn time.Duration) (string, error) {
 	expires := time.Now().Add(expiresIn).Unix()
-	signature := c.GenerateSignature(method, path, expires)
+	signature, err := c.signWith(c.newHMAC(), method, c.basePath()+path, expires, params)
+	if err != nil {
+		return "", err
+	}
 
-	return fmt.Sprintf("%s%s?X-Mos-AccessKey=%s&X-Mos-Expires=%d&X-Mos-Signature=%s",
-		c.BaseURL,
+	return c.buildPresignedURL(path, expires, signature, params), nil
+}
+
+// GeneratePresignedURLForIP creates a presigned URL that only works from
+// sourceIP. The address is sent as the X-Mos-SourceIP parameter and covered by
+// the signature, so it cannot be changed or stripped without invalidating the
+// URL. Both IPv4 and IPv6 addresses are accepted.
+//
+// Example:
+//
+//	u, err := client.GeneratePresignedURLForIP("GET", path, "203.0.113.7", time.Hour)
+func (c *Client) GeneratePresignedURLForIP(method, path, sourceIP string, expiresIn time.Duration) (string, error) {
+	ip := net.ParseIP(sourceIP)
+	if ip == nil {
+		return "", fmt.Errorf("%w: invalid source IP %q", ErrInvalidConfig, sourceIP)
+	}
+	params := url.Values{"X-Mos-SourceIP": {ip.String()}}
+	return c.presignURL(method, path, params, expiresIn)
+}
+
+// baseURL returns BaseURL without trailing slashes. API paths start with a
+// slash, so joining them to it always yields exactly one, even when BaseURL
+// was set directly rather than through NewClient.
+func (c *Client) baseURL() string {
+	return strings.TrimRight(c.BaseURL, "/")
+}
+
+// basePath returns the escaped path prefix of BaseURL without a trailing slash,
+// or "" when BaseURL has no path. It is prepended to API paths when signing.
+func (c *Client) basePath() string {
+	u, err := url.Parse(c.baseURL())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(u.EscapedPath(), "/")
+}
+
+// buildPresignedURL assembles a presigned URL from an already computed signature.
+func (c *Client) buildPresignedURL(path string, expires int64, signature string, params url.Values) string {
+	encoded, _ := c.formatExpires(expires)
+	presignedURL := fmt.Sprintf("%s%s?X-Mos-AccessKey=%s&X-Mos-Expires=%s&X-Mos-Signature=%s&X-Mos-SignatureVersion=%s",
+		c.baseURL(),
 		path,
 		c.AccessKey,
-		expires,
+		url.QueryEscape(encoded),
 		url.QueryEscape(signature),
+		c.signatureVersion(),
+	)
+	if query := canonicalQuery(params); query != "" {
+		presignedURL += "&" + query
+	}
+
+	return presignedURL
+}
+
+// SignRequest presigns an existing request in place. The signature is computed
+// from the request's method, path and any query parameters already present, then
+// the X-Mos-* authentication parameters are added to the URL query.
+// Use it when you build requests yourself but want the SDK to authenticate them.
+//
+// Example:
+//
+//	req, _ := http.NewRequest("GET", client.BaseURL+path, nil)
+//	req.Header.Set("Range", "bytes=0-1023")
+//	if err := client.SignRequest(req, time.Hour); err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := http.DefaultClient.Do(req)
+func (c *Client) SignRequest(req *http.Request, expiresIn time.Duration) error {
+	if req == nil || req.URL == nil {
+		return fmt.Errorf("cannot sign a request without a URL")
+	}
+
+	query := req.URL.Query()
+	expires := time.Now().Add(expiresIn).Unix()
+	signature, err := c.signWith(c.newHMAC(), req.Method, req.URL.EscapedPath(), expires, query)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := c.formatExpires(expires)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	query.Set("X-Mos-AccessKey", c.AccessKey)
+	query.Set("X-Mos-Expires", encoded)
+	query.Set("X-Mos-Signature", signature)
+	query.Set("X-Mos-SignatureVersion", string(c.signatureVersion()))
+	req.URL.RawQuery = query.Encode()
+
+	return nil
+}
+
+// RefreshURL re-signs an existing presigned GET URL for an object in the client's
+// bucket with a new expiry. Extra signed parameters (such as versionId or image
+// options) are preserved. URLs that do not point at an object route of the
+// client's bucket are rejected.
+//
+// Example:
+//
+//	fresh, err := client.RefreshURL(sharedURL, 24*time.Hour)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) RefreshURL(rawurl string, expiresIn time.Duration) (string, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", rawurl, err)
+	}
+
+	path := strings.TrimPrefix(u.EscapedPath(), c.basePath())
+	prefix := c.objectsPath() + "/"
+	if !strings.HasPrefix(path, prefix) || len(path) == len(prefix) {
+		return "", fmt.Errorf("URL %q is not an object URL of bucket %q", rawurl, c.BucketName)
+	}
+
+	return c.presignURL("GET", path, u.Query(), expiresIn)
+}
+
+// defaultAPIVersion is the API version used when Client.APIVersion is empty.
+const defaultAPIVersion = "v1"
+
+// apiRoot returns the versioned root of all API paths, e.g. "/api/v1".
+func (c *Client) apiRoot() string {
+	version := c.APIVersion
+	if version == "" {
+		version = defaultAPIVersion
+	}
+	return "/api/" + version
+}
+
+// objectsPath returns the API path of the object collection in the client's bucket.
+// Project and bucket segments are path-escaped.
+func (c *Client) objectsPath() string {
+	return c.objectsPathIn(c.ProjectID, c.BucketName)
+}
+
+// objectsPathIn returns the API path of the object collection in another
+// project and bucket.
+func (c *Client) objectsPathIn(projectID, bucketName string) string {
+	return fmt.Sprintf("%s/projects/%s/buckets/%s/objects",
+		c.apiRoot(),
+		url.PathEscape(projectID),
+		url.PathEscape(bucketName),
 	)
 }
 
+// objectPath returns the API path of a single object. The filename is
+// path-escaped, and the escaped form is what gets signed, so the signature
+// always matches the path the server receives.
+func (c *Client) objectPath(filename string) string {
+	return c.objectsPath() + "/" + escapeKey(filename)
+}
+
+// escapeKey path-escapes an object key segment by segment. Slashes are kept as
+// separators so nested keys such as "users/42/avatar.png" address a single
+// object; leading slashes are ignored.
+func escapeKey(key string) string {
+	segments := strings.Split(strings.TrimLeft(key, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
 // GetObjectURL generates a presigned URL for downloading/viewing an object.
+// Filenames may contain slashes to address nested keys (e.g. "users/42/avatar.png").
+//
+// When URLCache is set, a URL previously generated for the same object and
+// expiresIn is returned until it nears expiry.
 //
 // Example:
 //
 //	url := client.GetObjectURL("photo.jpg", time.Hour)
 func (c *Client) GetObjectURL(filename string, expiresIn time.Duration) string {
-	path := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects/%s", c.ProjectID, c.BucketName, filename)
-	return c.GeneratePresignedURL("GET", path, expiresIn)
+	path := c.objectPath(filename)
+	if c.URLCache == nil {
+		return c.GeneratePresignedURL("GET", path, expiresIn)
+	}
+
+	// The key includes everything else the URL depends on, so a cache may be
+	// shared between clients. The secret is only included as a fingerprint.
+	secret := sha256.Sum256([]byte(c.SecretKey))
+	key := strings.Join([]string{
+		"GET", c.baseURL(), path, c.AccessKey, expiresIn.String(),
+		string(c.signatureVersion()), string(c.expiresFormat()), hex.EncodeToString(secret[:]),
+	}, "\n")
+	return c.URLCache.get(key, expiresIn, func() string {
+		return c.GeneratePresignedURL("GET", path, expiresIn)
+	})
+}
+
+// defaultMaxURLExpiry is the longest lifetime GetObjectURLSeconds signs for
+// when MaxURLExpiry is not set.
+const defaultMaxURLExpiry = 7 * 24 * time.Hour
+
+// GetObjectURLSeconds is GetObjectURL with the lifetime given in seconds, for
+// values coming from external configuration. It fails with ErrInvalidConfig
+// for seconds <= 0 and clamps longer lifetimes to MaxURLExpiry (default:
+// 7 days, 604800 seconds).
+//
+// Example:
+//
+//	url, err := client.GetObjectURLSeconds("photo.jpg", cfg.LinkTTLSeconds)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) GetObjectURLSeconds(filename string, seconds int64) (string, error) {
+	if seconds <= 0 {
+		return "", fmt.Errorf("%w: URL lifetime must be positive, got %d seconds", ErrInvalidConfig, seconds)
+	}
+	if err := c.checkSigning(); err != nil {
+		return "", err
+	}
+
+	maxExpiry := c.MaxURLExpiry
+	if maxExpiry <= 0 {
+		maxExpiry = defaultMaxURLExpiry
+	}
+	// Compare in seconds first so huge values cannot overflow the Duration
+	expiresIn := maxExpiry
+	if seconds < int64(maxExpiry/time.Second) {
+		expiresIn = time.Duration(seconds) * time.Second
+	}
+	return c.GetObjectURL(filename, expiresIn), nil
+}
+
+// PresignFromResponse generates a presigned URL for method on the object
+// described by resp, e.g. a private, expiring link right after an upload.
+// The object key comes from resp.ObjectKey. The project and bucket are taken
+// from resp.URL when it has the default .../projects/{id}/buckets/{name}/...
+// form and default to the client's otherwise, since resp.BucketID is an ID
+// rather than the bucket name used in paths. It returns "" if resp has no
+// object key.
+//
+// Example:
+//
+//	resp, _ := client.Upload("report.pdf", nil)
+//	link := client.PresignFromResponse(resp, "GET", 24*time.Hour)
+func (c *Client) PresignFromResponse(resp *FileResponse, method string, expiresIn time.Duration) string {
+	if resp == nil {
+		return ""
+	}
+	key := resp.ObjectKey()
+	if key == "" {
+		return ""
+	}
+
+	projectID, bucketName := c.ProjectID, c.BucketName
+	if u, err := url.Parse(resp.URL); err == nil {
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for i := 0; i+3 < len(segments); i++ {
+			if segments[i] != "projects" || segments[i+2] != "buckets" {
+				continue
+			}
+			p, perr := url.PathUnescape(segments[i+1])
+			b, berr := url.PathUnescape(segments[i+3])
+			if perr == nil && berr == nil {
+				projectID, bucketName = p, b
+			}
+			break
+		}
+	}
+
+	path := c.objectsPathIn(projectID, bucketName) + "/" + escapeKey(key)
+	return c.GeneratePresignedURL(method, path, expiresIn)
+}
+
+// GetObjectHeadURL generates a presigned URL for a HEAD request on an object,
+// letting a client (including a browser via fetch) check existence, size and
+// ETag without downloading the content. HeadObject performs the same request
+// from the SDK.
+//
+// Example:
+//
+//	url := client.GetObjectHeadURL("photo.jpg", time.Hour)
+//	// fetch(url, {method: "HEAD"})
+func (c *Client) GetObjectHeadURL(filename string, expiresIn time.Duration) string {
+	return c.GeneratePresignedURL("HEAD", c.objectPath(filename), expiresIn)
 }
 
 // UploadObjectURL generates a presigned URL for uploading an object.
@@ -112,17 +727,48 @@ func (c *Client) GetObjectURL(filename string, expiresIn time.Duration) string {
 //	url := client.UploadObjectURL(time.Hour)
 //	// Use this URL with a multipart/form-data POST request
 func (c *Client) UploadObjectURL(expiresIn time.Duration) string {
-	path := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects", c.ProjectID, c.BucketName)
+	path := c.objectsPath()
 	return c.GeneratePresignedURL("POST", path, expiresIn)
 }
 
+// UploadObjectURLForContentTypes generates a presigned upload URL that only
+// accepts files of the given content types. Each type is signed into the URL
+// as a content-type parameter and may use a wildcard subtype such as
+// "image/*"; the server rejects uploads whose file part has another type.
+// Because the constraint is signed, a client handed the URL cannot remove
+// it. Without content types it is equivalent to UploadObjectURL.
+//
+// Example:
+//
+//	// Hand out an URL that only accepts images
+//	url := client.UploadObjectURLForContentTypes(15*time.Minute, "image/png", "image/jpeg")
+func (c *Client) UploadObjectURLForContentTypes(expiresIn time.Duration, contentTypes ...string) string {
+	params := url.Values{}
+	for _, contentType := range contentTypes {
+		params.Add("content-type", contentType)
+	}
+	return c.GeneratePresignedURLWithParams("POST", c.objectsPath(), params, expiresIn)
+}
+
+// PutObjectURL generates a presigned URL for uploading an object with a raw
+// PUT request. Unlike UploadObjectURL the object path includes the filename.
+//
+// Example:
+//
+//	url := client.PutObjectURL("report.pdf", time.Hour)
+//	// Use this URL with a PUT request whose body is the raw file content
+func (c *Client) PutObjectURL(filename string, expiresIn time.Duration) string {
+	path := c.objectPath(filename)
+	return c.GeneratePresignedURL("PUT", path, expiresIn)
+}
+
 // DeleteObjectURL generates a presigned URL for deleting an object.
 //
 // Example:
 //
 //	url := client.DeleteObjectURL("photo.jpg", time.Hour)
 func (c *Client) DeleteObjectURL(filename string, expiresIn time.Duration) string {
-	path := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects/%s", c.ProjectID, c.BucketName, filename)
+	path := c.objectPath(filename)
 	return c.GeneratePresignedURL("DELETE", path, expiresIn)
 }
 
@@ -136,13 +782,43 @@ func (c *Client) DeleteObjectURL(filename string, expiresIn time.Duration) strin
 //
 //	url := client.GetPublicObjectURL("photo.jpg")
 //	// Returns: https://storage.example.com/api/v1/public/projects/{projectId}/buckets/{bucket}/photo.jpg
+//
+// When PublicURLTemplate is set it is used instead of the default route. When
+// DisablePublicURLs is set it returns ""; use PublicObjectURL to get an error.
 func (c *Client) GetPublicObjectURL(filename string) string {
-	return fmt.Sprintf("%s/api/v1/public/projects/%s/buckets/%s/%s",
-		c.BaseURL,
-		c.ProjectID,
-		c.BucketName,
-		filename,
-	)
+	u, _ := c.PublicObjectURL(filename)
+	return u
+}
+
+// PublicObjectURL is GetPublicObjectURL that fails with ErrPublicURLsDisabled
+// instead of returning "" when DisablePublicURLs is set.
+//
+// Example:
+//
+//	u, err := client.PublicObjectURL("logo.png")
+//	if errors.Is(err, sdk.ErrPublicURLsDisabled) {
+//	    u = client.GetObjectURL("logo.png", time.Hour)
+//	}
+func (c *Client) PublicObjectURL(filename string) (string, error) {
+	if c.DisablePublicURLs {
+		return "", fmt.Errorf("%w: cannot build a public URL for %s", ErrPublicURLsDisabled, filename)
+	}
+
+	if c.PublicURLTemplate != "" {
+		return strings.NewReplacer(
+			"{projectID}", url.PathEscape(c.ProjectID),
+			"{bucket}", url.PathEscape(c.BucketName),
+			"{filename}", escapeKey(filename),
+		).Replace(c.PublicURLTemplate), nil
+	}
+
+	return fmt.Sprintf("%s%s/public/projects/%s/buckets/%s/%s",
+		c.baseURL(),
+		c.apiRoot(),
+		url.PathEscape(c.ProjectID),
+		url.PathEscape(c.BucketName),
+		escapeKey(filename),
+	), nil
 }
 
 // PresignedURLOptions provides additional options for URL generation.
@@ -161,10 +837,99 @@ func DefaultOptions() PresignedURLOptions {
 type UploadOptions struct {
 	Metadata  map[string]interface{} // Optional metadata to attach to the file
 	ExpiresIn time.Duration          // URL expiration time (default: 1 hour)
+	MaxSize   int64                  // Maximum allowed size in bytes, checked before sending (default: 0, no limit)
+	Compress  bool                   // Gzip the payload and send Content-Encoding: gzip (server must support it)
+
+	// AllowedContentTypes rejects uploads whose content type is not listed.
+	// Both the type of the file extension and the type sniffed from the
+	// content must match, so a mislabeled file is rejected. Entries may use
+	// a wildcard subtype such as "image/*" (default: any type).
+	AllowedContentTypes []string
+
+	// VerifyAfterUpload issues a HEAD request after a successful upload and
+	// fails with ErrVerifyFailed if the stored size differs from the bytes
+	// sent (before compression). It costs an extra round trip (default: off).
+	VerifyAfterUpload bool
+
+	// EncryptionKeyID asks the server to encrypt the object at rest with this
+	// customer-managed key, sent as the X-Mos-SSE-KeyId header. The key the
+	// server used is reported in FileResponse.EncryptionKeyID.
+	EncryptionKeyID string
+
+	// CacheControl is stored with the object and returned as its
+	// Cache-Control header on downloads, e.g. "public, max-age=31536000,
+	// immutable" for content-addressed assets. It is sent as the
+	// X-Mos-Cache-Control header (default: the server's policy).
+	CacheControl string
+
+	// OnProgress, if set, is called as the request body is sent with the
+	// bytes sent so far and the body's total size (-1 when streamed with
+	// UploadReader). Multipart sizes include the form framing. Build custom
+	// progress pipelines with NewProgressReader instead.
+	OnProgress func(sent, total int64)
+
+	// Timeout bounds the upload request, including sending the body and
+	// reading the response (default: 0, only the client's own timeouts apply).
+	Timeout time.Duration
+
+	// Extension sets the file extension (e.g. "pdf" or ".pdf") of the name
+	// sent to the server, replacing any extension of the local name, so the
+	// stored filename gets the right suffix when uploading nameless data.
+	Extension string
+
+	// ObjectKey asks the server to store the object under this key instead of
+	// a generated UUID filename, e.g. a content hash for deduplicated uploads.
+	// The key is signed into the upload URL and sent as the "key" form field.
+	ObjectKey string
+
+	// SkipIfExists makes Upload and UploadBytes skip the upload when the
+	// target object already holds the same content (same size and, if its
+	// ETag is an MD5, same hash), returning the existing object with Skipped
+	// set. The target is ObjectKey or, when that is empty, the content's
+	// SHA-256 (see ContentHash) plus the file extension, so identical content
+	// is stored once under a content-addressed key. Costs a HEAD request.
+	SkipIfExists bool
+
+	// FileFieldName is the multipart form field carrying the file content,
+	// for servers that expect e.g. "upload" or "object" (default: "file").
+	FileFieldName string
+
+	// MetadataEncoding selects how Metadata is written to the multipart form
+	// (default: MetadataJSONBlob).
+	MetadataEncoding MetadataEncoding
+
+	// MetadataFieldName overrides the form field name used for metadata.
+	// For MetadataJSONBlob it is the field name (default: "metadata"). For
+	// MetadataFormFields it is a prefix for the key, e.g. "x-amz-meta-", or
+	// a name with a single %s replaced by the key (default: "meta[%s]").
+	MetadataFieldName string
+
+	// SpoolToDisk makes UploadReader buffer the form in a temporary file so
+	// it can send a Content-Length, for servers that reject chunked uploads
+	// with 411 Length Required (ErrLengthRequired). The spooled body is also
+	// resent on retries (default: off, stream with chunked encoding).
+	SpoolToDisk bool
+
+	// Concurrency limits the number of parallel uploads in UploadDir (default: 4).
+	Concurrency int
+
+	// FollowSymlinks makes UploadDir upload the targets of symlinked files.
+	// Symlinks are skipped by default; symlinked directories are never entered.
+	FollowSymlinks bool
+}
+
+// checkSize returns ErrTooLarge if size exceeds the MaxSize limit in opts.
+func (opts *UploadOptions) checkSize(size int64) error {
+	if opts.MaxSize > 0 && size > opts.MaxSize {
+		return fmt.Errorf("%w: %d bytes exceeds limit of %d bytes", ErrTooLarge, size, opts.MaxSize)
+	}
+	return nil
 }
 
 // Upload uploads a file from the local filesystem and returns the server response.
 // The returned FileResponse contains the actual URL with server-generated UUID filename.
+// The multipart body is built in memory, so when MaxRetries is set every retry
+// resends the complete file rather than the already-consumed *os.File.
 //
 // Example:
 //
@@ -186,6 +951,8 @@ func (c *Client) Upload(filePath string, opts *UploadOptions) (*FileResponse, er
 		opts.ExpiresIn = time.Hour
 	}
 
+	filename := opts.withExtension(filepath.Base(filePath))
+
 	// Open file
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -193,78 +960,173 @@ func (c *Client) Upload(filePath string, opts *UploadOptions) (*FileResponse, er
 	}
 	defer file.Close()
 
-	// Create multipart form
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	// Add file
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
-	}
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("failed to copy file data: %w", err)
+	// Enforce size limit before reading the file
+	if opts.MaxSize > 0 {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat file: %w", err)
+		}
+		if err := opts.checkSize(info.Size()); err != nil {
+			return nil, err
+		}
 	}
 
-	// Add metadata if provided
-	if opts.Metadata != nil {
-		metadataJSON, err := json.Marshal(opts.Metadata)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	// Sniff the content type from the first bytes, then rewind
+	if len(opts.AllowedContentTypes) > 0 {
+		head := make([]byte, 512)
+		n, err := io.ReadFull(file, head)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if err := opts.checkContentType(filename, head[:n]); err != nil {
+			return nil, err
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind file: %w", err)
 		}
-		writer.WriteField("metadata", string(metadataJSON))
 	}
 
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	if opts.SkipIfExists {
+		existing, uploadOpts, err := c.findExisting(filename, file, opts)
+		if err != nil || existing != nil {
+			return existing, err
+		}
+		opts = uploadOpts
 	}
 
-	// Generate presigned URL
-	uploadURL := c.UploadObjectURL(opts.ExpiresIn)
+	return c.upload(filename, file, opts)
+}
+
+// UploadBytes uploads file content from memory (byte slice) and returns the server response.
+// Useful for uploading generated content, images from memory, or data from other sources.
+//
+// Example:
+//
+//	data := []byte("Hello, World!")
+//	resp, err := client.UploadBytes("hello.txt", data, &sdk.UploadOptions{
+//	    Metadata: map[string]interface{}{"type": "text"},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("File uploaded: %s\n", resp.URL)
+func (c *Client) UploadBytes(filename string, data []byte, opts *UploadOptions) (*FileResponse, error) {
+	// Set defaults
+	if opts == nil {
+		opts = &UploadOptions{ExpiresIn: time.Hour}
+	}
+	if opts.ExpiresIn == 0 {
+		opts.ExpiresIn = time.Hour
+	}
+	filename = opts.withExtension(filename)
+
+	// Enforce size and content type limits
+	if err := opts.checkSize(int64(len(data))); err != nil {
+		return nil, err
+	}
+	if err := opts.checkContentType(filename, data); err != nil {
+		return nil, err
+	}
+
+	if opts.SkipIfExists {
+		existing, uploadOpts, err := c.findExisting(filename, bytes.NewReader(data), opts)
+		if err != nil || existing != nil {
+			return existing, err
+		}
+		opts = uploadOpts
+	}
+
+	return c.upload(filename, bytes.NewReader(data), opts)
+}
+
+// upload sends the content of r as a multipart upload and parses the response.
+func (c *Client) upload(filename string, r io.Reader, opts *UploadOptions) (*FileResponse, error) {
+	// Create multipart form, counting the payload for verification
+	counter := NewProgressReader(r, nil)
+	body, contentType, err := buildMultipartBody(filename, counter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.postUpload(context.Background(), body, 0, contentType, counter, opts)
+}
+
+// postUpload sends a multipart upload body with the given Content-Type and
+// parses the response. A positive size sets Content-Length, a negative one
+// sends the body with chunked transfer encoding and zero keeps the length
+// http.NewRequest derives from body. counter reports the file bytes sent.
+// Cancelling ctx aborts the request.
+func (c *Client) postUpload(ctx context.Context, body io.Reader, size int64, contentType string, counter *ProgressReader, opts *UploadOptions) (*FileResponse, error) {
+	// Generate presigned URL, bound to the requested key if any
+	var params url.Values
+	if opts.ObjectKey != "" {
+		params = url.Values{"key": {opts.ObjectKey}}
+	}
+	uploadURL, err := c.presignURL("POST", c.objectsPath(), params, opts.ExpiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withTimeout(ctx, opts.Timeout)
+	defer cancel()
 
 	// Create request
-	req, err := http.NewRequest("POST", uploadURL, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", uploadURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if size != 0 {
+		req.ContentLength = size
+	}
+	rewindable(req, body)
+	trackProgress(req, opts.OnProgress)
+	req.Header.Set("Content-Type", contentType)
+	opts.setHeaders(req)
+	chunked := req.ContentLength < 0
+	streamed := req.GetBody == nil
 
 	// Send request
-	resp, err := http.DefaultClient.Do(req)
+	start := time.Now()
+	resp, err := c.do("upload", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Check status
-	if resp.StatusCode != http.StatusCreated {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	// Check status; a streamed body may still be written when the server
+	// answers, so its count does not tell whether the content was empty
+	if !isSuccess(resp.StatusCode) {
+		return nil, uploadError(resp, !streamed && counter.BytesRead() == 0)
 	}
 
 	// Parse response
-	var fileResp FileResponse
-	if err := json.NewDecoder(resp.Body).Decode(&fileResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	fileResp, err := parseUploadResponse(resp, opts.ObjectKey)
+	fileResp.Stats = newUploadStats(counter.BytesRead(), start)
+	fileResp.Stats.Chunked = chunked
+	if err != nil {
+		return fileResp, err
 	}
 
-	return &fileResp, nil
+	if opts.VerifyAfterUpload {
+		return fileResp, c.verifyUpload(fileResp, opts.ObjectKey, counter.BytesRead())
+	}
+	return fileResp, nil
 }
 
-// UploadBytes uploads file content from memory (byte slice) and returns the server response.
-// Useful for uploading generated content, images from memory, or data from other sources.
+// UploadPut uploads file content from memory with a single PUT request instead of
+// a multipart form. The body is sent byte-for-byte, which avoids the multipart
+// overhead for binary blobs. The Content-Type is derived from the filename's
+// extension, falling back to content sniffing. Metadata, if any, is sent as JSON
+// in the X-Mos-Metadata header.
 //
 // Example:
 //
-//	data := []byte("Hello, World!")
-//	resp, err := client.UploadBytes("hello.txt", data, &sdk.UploadOptions{
-//	    Metadata: map[string]interface{}{"type": "text"},
-//	})
+//	resp, err := client.UploadPut("backup.bin", data, nil)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("File uploaded: %s\n", resp.URL)
-func (c *Client) UploadBytes(filename string, data []byte, opts *UploadOptions) (*FileResponse, error) {
+func (c *Client) UploadPut(filename string, data []byte, opts *UploadOptions) (*FileResponse, error) {
 	// Set defaults
 	if opts == nil {
 		opts = &UploadOptions{ExpiresIn: time.Hour}
@@ -272,67 +1134,334 @@ func (c *Client) UploadBytes(filename string, data []byte, opts *UploadOptions)
 	if opts.ExpiresIn == 0 {
 		opts.ExpiresIn = time.Hour
 	}
+	filename = opts.withExtension(filename)
 
-	// Create multipart form
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	// Add file
-	part, err := writer.CreateFormFile("file", filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+	// Enforce size and content type limits
+	if err := opts.checkSize(int64(len(data))); err != nil {
+		return nil, err
 	}
-	if _, err := part.Write(data); err != nil {
-		return nil, fmt.Errorf("failed to write file data: %w", err)
+	if err := opts.checkContentType(filename, data); err != nil {
+		return nil, err
 	}
 
-	// Add metadata if provided
-	if opts.Metadata != nil {
-		metadataJSON, err := json.Marshal(opts.Metadata)
+	// Compress body if requested
+	payload := data
+	if opts.Compress {
+		compressed, err := gzipBytes(data)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+			return nil, fmt.Errorf("failed to compress file data: %w", err)
 		}
-		writer.WriteField("metadata", string(metadataJSON))
+		payload = compressed
 	}
 
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	// Generate presigned URL
+	key := filename
+	if opts.ObjectKey != "" {
+		key = opts.ObjectKey
 	}
+	uploadURL := c.PutObjectURL(key, opts.ExpiresIn)
 
-	// Generate presigned URL
-	uploadURL := c.UploadObjectURL(opts.ExpiresIn)
+	ctx, cancel := withTimeout(context.Background(), opts.Timeout)
+	defer cancel()
 
 	// Create request
-	req, err := http.NewRequest("POST", uploadURL, body)
+	req, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, bytes.NewReader(payload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Content-Type", detectContentType(filename, data))
+	if opts.Compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	opts.setHeaders(req)
+	trackProgress(req, opts.OnProgress)
+
+	// Add metadata if provided
+	if len(opts.Metadata) > 0 {
+		metadataJSON, err := json.Marshal(opts.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		req.Header.Set("X-Mos-Metadata", string(metadataJSON))
+	}
 
 	// Send request
-	resp, err := http.DefaultClient.Do(req)
+	start := time.Now()
+	resp, err := c.do("upload", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Check status
-	if resp.StatusCode != http.StatusCreated {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	// Check status (PUT may create or replace the object)
+	if !isSuccess(resp.StatusCode) {
+		return nil, uploadError(resp, len(data) == 0)
 	}
 
 	// Parse response
-	var fileResp FileResponse
-	if err := json.NewDecoder(resp.Body).Decode(&fileResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	fileResp, err := parseUploadResponse(resp, key)
+	fileResp.Stats = newUploadStats(int64(len(data)), start)
+	if err != nil {
+		return fileResp, err
 	}
 
-	return &fileResp, nil
+	if opts.VerifyAfterUpload {
+		return fileResp, c.verifyUpload(fileResp, key, int64(len(data)))
+	}
+	return fileResp, nil
+}
+
+// verifyUpload checks with a HEAD request that the uploaded object has the
+// expected size. The object is looked up by the key in the server response,
+// falling back to key. Mismatches are reported as ErrVerifyFailed.
+func (c *Client) verifyUpload(fileResp *FileResponse, key string, size int64) error {
+	if k := fileResp.ObjectKey(); k != "" {
+		key = k
+	}
+	if key == "" {
+		return fmt.Errorf("%w: server response does not identify the object", ErrVerifyFailed)
+	}
+
+	info, err := c.HeadObject(key)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrVerifyFailed, err)
+	}
+	if info.Size != size {
+		return fmt.Errorf("%w: stored object %s has %d bytes, sent %d", ErrVerifyFailed, key, info.Size, size)
+	}
+	return nil
+}
+
+// withTimeout derives a context bounded by timeout from ctx. A zero timeout
+// only adds cancellation.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// contextReader stops reading with ctx.Err() once ctx is done, so a copy from
+// a slow body ends promptly on cancellation.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// parseUploadResponse decodes the FileResponse of a successful upload and
+// fills in fields the server reports through headers. An empty body yields a
+// FileResponse populated from the headers and key, the key the object was
+// stored under if known. So does a body that is not JSON, such as an HTML
+// page injected by a proxy, but then the error matches
+// ErrUnparseableResponse: the upload itself succeeded.
+func parseUploadResponse(resp *http.Response, key string) (*FileResponse, error) {
+	fileResp := &FileResponse{}
+
+	body, err := io.ReadAll(resp.Body)
+	if err == nil && len(bytes.TrimSpace(body)) > 0 {
+		if jsonErr := json.Unmarshal(body, fileResp); jsonErr != nil {
+			// Discard fields decoded before the error
+			fileResp = &FileResponse{}
+			err = fmt.Errorf("%w: %v", ErrUnparseableResponse, jsonErr)
+		}
+	} else if err != nil {
+		err = fmt.Errorf("%w: %v", ErrUnparseableResponse, err)
+	}
+
+	if fileResp.Name == "" && fileResp.URL == "" {
+		fileResp.Name = key
+		fileResp.URL = resp.Header.Get("Location")
+	}
+	if fileResp.ETag == "" {
+		fileResp.ETag = resp.Header.Get("ETag")
+	}
+	if fileResp.EncryptionKeyID == "" {
+		fileResp.EncryptionKeyID = resp.Header.Get(sseKeyHeader)
+	}
+	fileResp.RequestID = resp.Header.Get(requestIDHeader)
+	return fileResp, err
+}
+
+// uploadError builds the error for a failed upload. When the content was
+// empty and the server rejected the request as invalid, the APIError is
+// wrapped to say so, since some servers do not accept empty objects.
+func uploadError(resp *http.Response, empty bool) error {
+	apiErr := newAPIError("upload", resp)
+	if empty && (apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+		return fmt.Errorf("server rejected empty upload: %w", apiErr)
+	}
+	return apiErr
+}
+
+// sseKeyHeader carries the server-side encryption key of an object.
+const sseKeyHeader = "X-Mos-SSE-KeyId"
+
+// cacheControlHeader carries the Cache-Control value to store with an object.
+// A plain Cache-Control header would be a directive for the upload request
+// itself rather than object metadata.
+const cacheControlHeader = "X-Mos-Cache-Control"
+
+// setHeaders adds the encryption and caching headers requested in opts.
+func (opts *UploadOptions) setHeaders(req *http.Request) {
+	if opts.EncryptionKeyID != "" {
+		req.Header.Set(sseKeyHeader, opts.EncryptionKeyID)
+	}
+	if opts.CacheControl != "" {
+		req.Header.Set(cacheControlHeader, opts.CacheControl)
+	}
+}
+
+// withExtension returns filename with its extension replaced by the Extension
+// hint in opts, or filename unchanged when no hint is set.
+func (opts *UploadOptions) withExtension(filename string) string {
+	if opts.Extension == "" {
+		return filename
+	}
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + "." + strings.TrimPrefix(opts.Extension, ".")
+}
+
+// checkContentType returns ErrDisallowedType unless both the type implied by
+// filename's extension (if known) and the type sniffed from the first bytes
+// of the content are in AllowedContentTypes, so renaming a file does not get
+// it past the check. Sniffing cannot tell text formats such as JSON or CSV
+// from plain text, nor most binary formats (e.g. .docx, HEIC, Parquet) from
+// generic zip or octet-stream data, so such sniffs defer to the extension
+// unless it names a type the sniffer would have recognized.
+func (opts *UploadOptions) checkContentType(filename string, head []byte) error {
+	if len(opts.AllowedContentTypes) == 0 {
+		return nil
+	}
+
+	sniffed := mediaTypeOf(http.DetectContentType(head))
+	byExtension := mediaTypeOf(mime.TypeByExtension(filepath.Ext(filename)))
+	if byExtension != "" {
+		if !opts.allowsType(byExtension) {
+			return fmt.Errorf("%w: %s", ErrDisallowedType, byExtension)
+		}
+		if isTextType(byExtension) && (sniffed == "text/plain" || sniffed == "text/xml") {
+			return nil
+		}
+		if (sniffed == "application/octet-stream" || sniffed == "application/zip") && !sniffableTypes[byExtension] {
+			return nil
+		}
+	}
+	if !opts.allowsType(sniffed) {
+		return fmt.Errorf("%w: content detected as %s", ErrDisallowedType, sniffed)
+	}
+	return nil
+}
+
+// allowsType reports whether mediaType matches an entry of AllowedContentTypes.
+func (opts *UploadOptions) allowsType(mediaType string) bool {
+	for _, allowed := range opts.AllowedContentTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == mediaType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// mediaTypeOf returns contentType without parameters such as charset.
+func mediaTypeOf(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
+
+// isTextType reports whether mediaType is a textual format that content
+// sniffing only recognizes as plain text or XML.
+func isTextType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "/json") || strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "/xml") || strings.HasSuffix(mediaType, "+xml")
+}
+
+// sniffableTypes are the types http.DetectContentType identifies from content.
+// A file with one of these extensions must also sniff as an allowed type, so
+// e.g. an executable renamed to .png is not let through as unknown data.
+var sniffableTypes = map[string]bool{
+	"application/ogg": true, "application/pdf": true, "application/postscript": true,
+	"application/vnd.ms-fontobject": true, "application/wasm": true,
+	"application/x-gzip": true, "application/x-rar-compressed": true, "application/zip": true,
+	"audio/aiff": true, "audio/basic": true, "audio/midi": true, "audio/mpeg": true, "audio/wave": true,
+	"font/collection": true, "font/otf": true, "font/ttf": true, "font/woff": true, "font/woff2": true,
+	"image/bmp": true, "image/gif": true, "image/jpeg": true, "image/png": true, "image/webp": true,
+	"image/x-icon": true, "text/html": true, "video/avi": true, "video/mp4": true, "video/webm": true,
+}
+
+// detectContentType returns the MIME type for filename based on its extension,
+// falling back to sniffing the first bytes of data.
+func detectContentType(filename string, data []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(filename)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(data)
+}
+
+// DownloadOptions provides options for download operations.
+type DownloadOptions struct {
+	ExpiresIn  time.Duration // URL expiration time (default: 1 hour)
+	Decompress bool          // Transparently decompress responses with Content-Encoding: gzip
+
+	// Conditional download: when the object is unchanged the server answers
+	// 304 and the download fails with an error matching ErrNotModified.
+	IfNoneMatch     string    // ETag from a previous download (sent as If-None-Match)
+	IfModifiedSince time.Time // Last-Modified from a previous download (sent as If-Modified-Since)
+
+	// VersionID selects a specific historical version in a versioned bucket.
+	// It is sent as a signed versionId parameter on a presigned URL.
+	VersionID string
+
+	// UsePublicURL downloads through the unauthenticated public URL instead of
+	// a presigned URL. Only works in beta mode where all buckets are public.
+	UsePublicURL bool
+
+	// Concurrency limits the number of parallel downloads in DownloadPrefix (default: 4).
+	Concurrency int
+
+	// SkipExisting makes DownloadPrefix keep local files whose size matches the
+	// object and, when the server reports an MD5 ETag, whose content matches it.
+	SkipExisting bool
+
+	// Timeout bounds the whole download, including reading the body and any
+	// resumed range requests (default: 0, only the client's own timeouts apply).
+	Timeout time.Duration
+
+	// ExpectedSize, when positive, is checked against the size of the written
+	// file before it replaces localPath. On a mismatch the file is removed and
+	// the download fails with an error matching ErrVerifyFailed. Only used by
+	// single-file downloads; DownloadPrefix ignores it.
+	ExpectedSize int64
+}
+
+// DownloadResult describes a completed download.
+type DownloadResult struct {
+	Size         int64     // Number of bytes written locally
+	ContentType  string    // Content-Type reported by the server
+	ETag         string    // Entity tag of the object, for later conditional downloads
+	LastModified time.Time // Last modification time of the object, zero if unknown
+
+	// Set by DownloadPrefix for each object.
+	Key     string // Object key
+	Path    string // Local path the object was written to
+	Skipped bool   // The local file was already up to date (SkipExisting)
+	Err     error  // Download error, nil on success
 }
 
 // Download downloads a file and saves it to the specified local path.
-// Uses the server-generated UUID filename to fetch the file.
+// Uses the server-generated UUID filename to fetch the file through a presigned
+// URL valid for expiresIn, so it works with private buckets. A zero expiresIn
+// defaults to one hour.
 //
 // Example:
 //
@@ -341,36 +1470,378 @@ func (c *Client) UploadBytes(filename string, data []byte, opts *UploadOptions)
 //	    log.Fatal(err)
 //	}
 func (c *Client) Download(filename string, localPath string, expiresIn time.Duration) error {
-	// Generate URL (use public URL in beta mode)
-	url := c.GetPublicObjectURL(filename)
+	_, err := c.DownloadWithOptions(filename, localPath, &DownloadOptions{ExpiresIn: expiresIn})
+	return err
+}
+
+// createDownloadTemp creates a uniquely named temporary file next to
+// localPath. Unlike os.CreateTemp, which always uses 0600, it is created with
+// 0666 so the process umask applies just as it does for os.Create.
+func createDownloadTemp(localPath string) (*os.File, error) {
+	for {
+		name := localPath + ".tmp-" + strconv.FormatUint(uint64(rand.Uint32()), 10)
+		file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o666)
+		if !os.IsExist(err) {
+			return file, err
+		}
+	}
+}
+
+// DownloadWithOptions downloads a file to the specified local path using the given
+// options. Content is written byte-for-byte as stored unless Decompress is set.
+//
+// The content is written to a temporary file in the same directory
+// (localPath + ".tmp-<random>") and renamed over localPath once complete, so
+// concurrent readers see either the old file or the new one, never a partial
+// write.
+//
+// Example:
+//
+//	result, err := client.DownloadWithOptions("app.log.gz", "app.log", &sdk.DownloadOptions{
+//	    Decompress: true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Downloaded %d bytes\n", result.Size)
+func (c *Client) DownloadWithOptions(filename, localPath string, opts *DownloadOptions) (*DownloadResult, error) {
+	return c.DownloadContext(context.Background(), filename, localPath, opts)
+}
+
+// DownloadContext is DownloadWithOptions with a context. Cancelling ctx aborts
+// the request and the copy to disk between chunks, even while the body is
+// still trickling in, and returns ctx.Err(). The partially written local file
+// is removed whenever the copy fails.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	result, err := client.DownloadContext(ctx, "video.mp4", "video.mp4", nil)
+//	if errors.Is(err, context.Canceled) {
+//	    log.Println("download cancelled")
+//	}
+func (c *Client) DownloadContext(ctx context.Context, filename, localPath string, opts *DownloadOptions) (*DownloadResult, error) {
+	// Set defaults
+	if opts == nil {
+		opts = &DownloadOptions{ExpiresIn: time.Hour}
+	}
+	if opts.ExpiresIn == 0 {
+		opts.ExpiresIn = time.Hour
+	}
+
+	ctx, cancel := withTimeout(ctx, opts.Timeout)
+	defer cancel()
 
 	// Download file
-	resp, err := http.Get(url)
+	resp, err := c.getObject(ctx, filename, opts)
 	if err != nil {
-		return fmt.Errorf("failed to download file: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("download failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	body, err := decodeBody(resp, opts.Decompress)
+	if err != nil {
+		return nil, err
 	}
+	defer body.Close()
 
-	// Create local file
-	file, err := os.Create(localPath)
+	// Write to a temporary file next to localPath, renamed into place only
+	// once the body is complete, so readers never see a partial file and an
+	// existing file survives a failed download.
+	file, err := createDownloadTemp(localPath)
 	if err != nil {
-		return fmt.Errorf("failed to create local file: %w", err)
+		return nil, fmt.Errorf("failed to create local file: %w", err)
 	}
-	defer file.Close()
+	tmpPath := file.Name()
 
-	// Copy data
-	if _, err := io.Copy(file, resp.Body); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+	// Any failure from here on, including a failed close, removes the
+	// partial file so it is never mistaken for a complete download. A file
+	// being replaced keeps its permissions.
+	written, err := c.copyObject(ctx, file, filename, opts, resp, body)
+	if info, statErr := os.Stat(localPath); err == nil && statErr == nil && info.Mode().IsRegular() {
+		err = file.Chmod(info.Mode().Perm())
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && opts.ExpectedSize > 0 {
+		err = checkFileSize(tmpPath, opts.ExpectedSize)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, localPath)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to save file: %w", err)
 	}
 
+	return newDownloadResult(resp, written), nil
+}
+
+// checkFileSize stats the file at path and reports ErrVerifyFailed unless it
+// holds exactly want bytes.
+func checkFileSize(path string, want int64) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Size() != want {
+		return fmt.Errorf("%w: local file has %d bytes, expected %d", ErrVerifyFailed, info.Size(), want)
+	}
 	return nil
 }
 
+// DownloadToWriter downloads a file and streams it to w, e.g. an
+// http.ResponseWriter, without touching disk. A truncated body is resumed
+// like in DownloadWithOptions; on error w may have received part of the
+// object.
+//
+// Example:
+//
+//	result, err := client.DownloadToWriter("8aabd7f7-1dbf-4ea4-8918-db66069746e7.pdf", w, nil)
+//	if err != nil {
+//	    log.Printf("download failed: %v", err)
+//	}
+func (c *Client) DownloadToWriter(filename string, w io.Writer, opts *DownloadOptions) (*DownloadResult, error) {
+	// Set defaults
+	if opts == nil {
+		opts = &DownloadOptions{ExpiresIn: time.Hour}
+	}
+	if opts.ExpiresIn == 0 {
+		opts.ExpiresIn = time.Hour
+	}
+
+	ctx, cancel := withTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	// Download file
+	resp, err := c.getObject(ctx, filename, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := decodeBody(resp, opts.Decompress)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	written, err := c.copyObject(ctx, w, filename, opts, resp, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write file data: %w", err)
+	}
+
+	return newDownloadResult(resp, written), nil
+}
+
+// newDownloadResult describes a completed download of written bytes from resp.
+func newDownloadResult(resp *http.Response, written int64) *DownloadResult {
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	return &DownloadResult{
+		Size:         written,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: lastModified,
+	}
+}
+
+// copyObject copies an object body to w until ctx is done. When the body is
+// not decoded, truncation is detected and resumed with resumeDownload.
+func (c *Client) copyObject(ctx context.Context, w io.Writer, filename string, opts *DownloadOptions, resp *http.Response, body io.Reader) (int64, error) {
+	written, err := c.copyBuffer(w, &contextReader{ctx: ctx, r: body})
+	if body == resp.Body {
+		written, err = c.resumeDownload(ctx, w, filename, opts, resp, written, err)
+	}
+	return written, err
+}
+
+// DownloadBytes downloads a file and returns its content in memory.
+// Useful for small objects that are processed directly rather than saved to disk.
+//
+// Example:
+//
+//	data, err := client.DownloadBytes("8aabd7f7-1dbf-4ea4-8918-db66069746e7.json", nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) DownloadBytes(filename string, opts *DownloadOptions) ([]byte, error) {
+	// Set defaults
+	if opts == nil {
+		opts = &DownloadOptions{ExpiresIn: time.Hour}
+	}
+	if opts.ExpiresIn == 0 {
+		opts.ExpiresIn = time.Hour
+	}
+
+	ctx, cancel := withTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	// Download file
+	resp, err := c.getObject(ctx, filename, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := decodeBody(resp, opts.Decompress)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(&contextReader{ctx: ctx, r: body})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file data: %w", err)
+	}
+
+	return data, nil
+}
+
+// DownloadResponse requests a file through a presigned URL valid for one hour and
+// returns the live HTTP response with its body unread, for callers that need
+// headers the SDK does not surface or want to stream the body themselves. The
+// caller must close the response body. Non-2xx responses are returned as
+// *APIError with the body already closed.
+//
+// Example:
+//
+//	resp, err := client.DownloadResponse("8aabd7f7-1dbf-4ea4-8918-db66069746e7.mp4")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer resp.Body.Close()
+//	fmt.Println(resp.Header.Get("X-RateLimit-Remaining"))
+//	io.Copy(w, resp.Body)
+func (c *Client) DownloadResponse(filename string) (*http.Response, error) {
+	return c.getObject(context.Background(), filename, &DownloadOptions{ExpiresIn: time.Hour})
+}
+
+// Open requests a file through a presigned URL valid for one hour and returns
+// its content as a stream, for processing an object while it arrives (for
+// example line by line) without buffering it or writing it to disk. The caller
+// must close the returned reader. Non-2xx responses are returned as *APIError.
+//
+// Example:
+//
+//	r, err := client.Open("8aabd7f7-1dbf-4ea4-8918-db66069746e7.ndjson")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer r.Close()
+//	scanner := bufio.NewScanner(r)
+//	for scanner.Scan() {
+//	    handle(scanner.Bytes())
+//	}
+func (c *Client) Open(filename string) (io.ReadCloser, error) {
+	resp, err := c.DownloadResponse(filename)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// resumeDownload checks that a copied response body was complete. When fewer
+// bytes than the announced Content-Length arrived, it requests the remainder
+// with Range requests (up to MaxRetries times, pinned to the original ETag) and
+// appends it to w. It returns the total bytes written, or ErrIncompleteDownload
+// if the object could not be completed.
+func (c *Client) resumeDownload(ctx context.Context, w io.Writer, filename string, opts *DownloadOptions, resp *http.Response, written int64, err error) (int64, error) {
+	total := resp.ContentLength
+	if total < 0 {
+		return written, err
+	}
+
+	for attempt := 0; err != nil || written < total; attempt++ {
+		if attempt >= c.MaxRetries {
+			if err != nil {
+				return written, fmt.Errorf("%w: received %d of %d bytes: %v", ErrIncompleteDownload, written, total, err)
+			}
+			return written, fmt.Errorf("%w: received %d of %d bytes", ErrIncompleteDownload, written, total)
+		}
+		timer := time.NewTimer(c.backoff().Next(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return written, ctx.Err()
+		}
+
+		part, rerr := c.getObjectRange(ctx, filename, opts, written, resp.Header.Get("ETag"))
+		if rerr != nil {
+			return written, rerr
+		}
+		if part.StatusCode != http.StatusPartialContent {
+			part.Body.Close()
+			return written, fmt.Errorf("%w: server did not honor the range request (status %d)", ErrIncompleteDownload, part.StatusCode)
+		}
+
+		var n int64
+		n, err = c.copyBuffer(w, &contextReader{ctx: ctx, r: part.Body})
+		part.Body.Close()
+		written += n
+	}
+
+	return written, nil
+}
+
+// getObject sends the GET request for an object and checks the response status.
+// On success the caller must close the response body.
+func (c *Client) getObject(ctx context.Context, filename string, opts *DownloadOptions) (*http.Response, error) {
+	return c.getObjectRange(ctx, filename, opts, 0, "")
+}
+
+// getObjectRange is getObject starting at byte offset. A non-zero offset sends
+// Range (and If-Range with etag, so a changed object is not spliced) and skips
+// the conditional headers.
+func (c *Client) getObjectRange(ctx context.Context, filename string, opts *DownloadOptions, offset int64, etag string) (*http.Response, error) {
+	// Generate presigned URL. Public URLs are only used when explicitly
+	// requested, and never for versions, which need the signed versionId.
+	url := c.GetObjectVersionURL(filename, opts.VersionID, opts.ExpiresIn)
+	if opts.UsePublicURL && opts.VersionID == "" {
+		publicURL, err := c.PublicObjectURL(filename)
+		if err != nil {
+			return nil, err
+		}
+		url = publicURL
+	}
+
+	// Create request
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept-Encoding", acceptEncoding(opts.Decompress))
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if etag != "" {
+			req.Header.Set("If-Range", etag)
+		}
+	} else {
+		if opts.IfNoneMatch != "" {
+			req.Header.Set("If-None-Match", opts.IfNoneMatch)
+		}
+		if !opts.IfModifiedSince.IsZero() {
+			req.Header.Set("If-Modified-Since", opts.IfModifiedSince.UTC().Format(http.TimeFormat))
+		}
+	}
+
+	// Send request
+	resp, err := c.do("download", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+
+	if !isSuccess(resp.StatusCode) {
+		defer resp.Body.Close()
+		return nil, newAPIError("download", resp)
+	}
+
+	return resp, nil
+}
+
 // Delete deletes a file from storage using presigned URL.
 //
 // Example:
@@ -380,8 +1851,190 @@ func (c *Client) Download(filename string, localPath string, expiresIn time.Dura
 //	    log.Fatal(err)
 //	}
 func (c *Client) Delete(filename string, expiresIn time.Duration) error {
+	return c.DeleteWithOptions(filename, &DeleteOptions{ExpiresIn: expiresIn})
+}
+
+// DeleteOptions provides options for delete operations.
+type DeleteOptions struct {
+	ExpiresIn time.Duration // URL expiration time (default: 1 hour)
+	VersionID string        // Delete only this version in a versioned bucket (sent as signed versionId)
+
+	// IfMatch deletes the object only if its current ETag equals this value
+	// (sent as If-Match). Otherwise the server answers 412 and the delete
+	// fails with an error matching ErrPreconditionFailed.
+	IfMatch string
+
+	// IgnoreNotFound treats a 404 response as success, so a delete retried
+	// after an attempt whose response was lost still returns nil.
+	IgnoreNotFound bool
+}
+
+// DeleteWithOptions deletes a file from storage using the given options.
+//
+// Example:
+//
+//	err := client.DeleteWithOptions("report.pdf", &sdk.DeleteOptions{
+//	    VersionID: "3HL4kqtJlcpXroDTDmJ",
+//	})
+//
+// Conditional delete, safe against a concurrent writer:
+//
+//	err := client.DeleteWithOptions("report.pdf", &sdk.DeleteOptions{IfMatch: info.ETag})
+//
+// Idempotent delete for cleanup jobs:
+//
+//	err := client.DeleteWithOptions("report.pdf", &sdk.DeleteOptions{IgnoreNotFound: true})
+//	if errors.Is(err, sdk.ErrPreconditionFailed) {
+//	    // the object changed since info was fetched
+//	}
+func (c *Client) DeleteWithOptions(filename string, opts *DeleteOptions) error {
+	_, err := c.DeleteWithResult(filename, opts)
+	return err
+}
+
+// DeleteResult describes what a delete removed, for servers that answer with
+// a JSON body instead of a bare 204. Fields are zero when the server sent no
+// details.
+type DeleteResult struct {
+	ObjectsRemoved int   `json:"objects_removed"` // Objects (or versions) removed
+	BytesFreed     int64 `json:"bytes_freed"`     // Storage reclaimed in bytes
+}
+
+// DeleteWithResult deletes a file like DeleteWithOptions and returns the
+// details the server reported. A missing or non-JSON body yields a zero
+// DeleteResult, since the delete itself succeeded.
+//
+// Example:
+//
+//	result, err := client.DeleteWithResult("backup.tar", nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Printf("freed %d bytes", result.BytesFreed)
+func (c *Client) DeleteWithResult(filename string, opts *DeleteOptions) (*DeleteResult, error) {
+	// Set defaults
+	if opts == nil {
+		opts = &DeleteOptions{ExpiresIn: time.Hour}
+	}
+	if opts.ExpiresIn == 0 {
+		opts.ExpiresIn = time.Hour
+	}
+
 	// Generate presigned delete URL
-	url := c.DeleteObjectURL(filename, expiresIn)
+	url, err := c.presignURL("DELETE", c.objectPath(filename), versionParams(opts.VersionID), opts.ExpiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create DELETE request
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if opts.IfMatch != "" {
+		req.Header.Set("If-Match", opts.IfMatch)
+	}
+
+	// Send request
+	resp, err := c.do("delete", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if opts.IgnoreNotFound && resp.StatusCode == http.StatusNotFound {
+		return &DeleteResult{}, nil
+	}
+	if !isSuccess(resp.StatusCode) {
+		return nil, newAPIError("delete", resp)
+	}
+
+	// Details are optional; a body that does not parse is ignored
+	var result DeleteResult
+	if body, err := io.ReadAll(resp.Body); err == nil && len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &result); err != nil {
+			result = DeleteResult{}
+		}
+	}
+	return &result, nil
+}
+
+// objectByIDPath returns the API path addressing an object by its ID rather
+// than by its server-generated filename.
+func (c *Client) objectByIDPath(id string) string {
+	return fmt.Sprintf("%s/projects/%s/buckets/%s/files/%s",
+		c.apiRoot(),
+		url.PathEscape(c.ProjectID),
+		url.PathEscape(c.BucketName),
+		url.PathEscape(id),
+	)
+}
+
+// GetObjectByID looks up an object by the ID returned in FileResponse.ID. Use it
+// to resolve an ID stored in your database to the object's filename and URL.
+//
+// Example:
+//
+//	obj, err := client.GetObjectByID("8aabd7f7-1dbf-4ea4-8918-db66069746e7")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(obj.Name)
+func (c *Client) GetObjectByID(id string) (*FileResponse, error) {
+	// Generate presigned URL
+	getURL, err := c.presignURL("GET", c.objectByIDPath(id), nil, DefaultOptions().ExpiresIn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create request
+	req, err := http.NewRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Send request
+	resp, err := c.do("get object", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !isSuccess(resp.StatusCode) {
+		return nil, newAPIError("get object", resp)
+	}
+
+	// Parse response
+	var fileResp FileResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fileResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	fileResp.RequestID = resp.Header.Get(requestIDHeader)
+
+	return &fileResp, nil
+}
+
+// DeleteByID deletes an object by the ID returned in FileResponse.ID, without
+// needing to know its server-generated filename. An expiresIn of 0 uses the
+// same default as Delete (1 hour).
+//
+// Example:
+//
+//	err := client.DeleteByID("8aabd7f7-1dbf-4ea4-8918-db66069746e7", time.Hour)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) DeleteByID(id string, expiresIn time.Duration) error {
+	// Set defaults
+	if expiresIn == 0 {
+		expiresIn = time.Hour
+	}
+
+	// Generate presigned delete URL
+	url, err := c.presignURL("DELETE", c.objectByIDPath(id), nil, expiresIn)
+	if err != nil {
+		return err
+	}
 
 	// Create DELETE request
 	req, err := http.NewRequest("DELETE", url, nil)
@@ -390,15 +2043,62 @@ func (c *Client) Delete(filename string, expiresIn time.Duration) error {
 	}
 
 	// Send request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.do("delete", req)
 	if err != nil {
 		return fmt.Errorf("failed to delete file: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("delete failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	if !isSuccess(resp.StatusCode) {
+		return newAPIError("delete", resp)
+	}
+
+	return nil
+}
+
+// pingTimeout bounds Ping independently of any timeout used for transfers.
+const pingTimeout = 5 * time.Second
+
+// Ping verifies that the storage endpoint is reachable and that the client's
+// credentials are accepted. It issues a lightweight presigned request against
+// the bucket and returns nil on success.
+//
+// Errors can be classified with errors.Is:
+//   - ErrUnreachable: the endpoint could not be contacted
+//   - ErrUnauthorized: the server rejected the credentials (401/403)
+//
+// Example:
+//
+//	if err := client.Ping(ctx); err != nil {
+//	    return fmt.Errorf("storage not ready: %w", err)
+//	}
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	path := c.objectsPath()
+	params := url.Values{"limit": {"1"}}
+	pingURL, err := c.presignURL("GET", path, params, time.Minute)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", pingURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := c.applyExtraHeaders(req); err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	defer resp.Body.Close()
+
+	if !isSuccess(resp.StatusCode) {
+		return newAPIError("ping", resp)
 	}
 
 	return nil
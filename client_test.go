@@ -1,11 +1,24 @@
 package sdk
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -40,6 +53,36 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestBaseURL_TrailingSlash(t *testing.T) {
+	want := NewClient(testBaseURL+"/storage", testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	viaConstructor := NewClient(testBaseURL+"/storage/", testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if viaConstructor.BaseURL != testBaseURL+"/storage" {
+		t.Errorf("NewClient should trim the trailing slash, got %s", viaConstructor.BaseURL)
+	}
+
+	// Set directly, bypassing NewClient
+	viaField := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	viaField.BaseURL = testBaseURL + "/storage//"
+
+	for _, client := range []*Client{viaConstructor, viaField} {
+		got := client.GetObjectURL("photo.jpg", time.Hour)
+		if err := want.VerifyPresignedURL("GET", got); err != nil || !strings.HasPrefix(got, testBaseURL+"/storage/api/") {
+			t.Errorf("expected the same URL as without a slash, got %s (%v)", got, err)
+		}
+		if strings.Contains(strings.TrimPrefix(got, "https://"), "//") {
+			t.Errorf("URL should not contain a double slash: %s", got)
+		}
+		if public := client.GetPublicObjectURL("photo.jpg"); public != want.GetPublicObjectURL("photo.jpg") {
+			t.Errorf("unexpected public URL %s", public)
+		}
+	}
+
+	if err := NewClient(testBaseURL+"?x=1", testProjectID, testBucketName, testAccessKey, testSecretKey).Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("base URL with a query should be rejected, got %v", err)
+	}
+}
+
 func TestGenerateSignature(t *testing.T) {
 	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
 
@@ -161,6 +204,93 @@ func TestGetObjectURL(t *testing.T) {
 	}
 }
 
+func TestGetObjectURLSeconds(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	for _, seconds := range []int64{0, -5} {
+		if _, err := client.GetObjectURLSeconds("photo.jpg", seconds); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("%d seconds: expected ErrInvalidConfig, got %v", seconds, err)
+		}
+	}
+
+	tests := []struct {
+		seconds int64
+		max     time.Duration
+		want    time.Duration
+	}{
+		{60, 0, time.Minute},
+		{1 << 62, 0, 7 * 24 * time.Hour},
+		{7200, time.Hour, time.Hour},
+	}
+	for _, tt := range tests {
+		client.MaxURLExpiry = tt.max
+		url, err := client.GetObjectURLSeconds("photo.jpg", tt.seconds)
+		if err != nil {
+			t.Fatalf("%d seconds: unexpected error %v", tt.seconds, err)
+		}
+		remaining, err := client.URLTimeRemaining(url)
+		if err != nil {
+			t.Fatalf("%d seconds: %v", tt.seconds, err)
+		}
+		if remaining > tt.want || remaining < tt.want-2*time.Second {
+			t.Errorf("%d seconds with max %s: expected lifetime %s, got %s", tt.seconds, tt.max, tt.want, remaining)
+		}
+	}
+}
+
+func TestPresignFromResponse(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	tests := []struct {
+		resp     *FileResponse
+		wantPath string
+	}{
+		{
+			&FileResponse{Name: "abc.jpg", URL: testBaseURL + "/api/v1/public/projects/p2/buckets/archive/abc.jpg"},
+			"/api/v1/projects/p2/buckets/archive/objects/abc.jpg",
+		},
+		{
+			&FileResponse{Name: "abc.jpg", URL: "https://cdn.example.com/abc.jpg"},
+			"/api/v1/projects/" + testProjectID + "/buckets/" + testBucketName + "/objects/abc.jpg",
+		},
+		{
+			&FileResponse{URL: testBaseURL + "/api/v1/public/projects/p2/buckets/archive/def.png"},
+			"/api/v1/projects/p2/buckets/archive/objects/def.png",
+		},
+	}
+
+	for _, tt := range tests {
+		presigned := client.PresignFromResponse(tt.resp, "GET", time.Hour)
+		u, err := url.Parse(presigned)
+		if err != nil || u.Path != tt.wantPath {
+			t.Errorf("%+v: expected path %s, got %s", tt.resp, tt.wantPath, presigned)
+			continue
+		}
+		if err := client.VerifyPresignedURL("GET", presigned); err != nil {
+			t.Errorf("%s should verify: %v", presigned, err)
+		}
+	}
+
+	if got := client.PresignFromResponse(&FileResponse{}, "GET", time.Hour); got != "" {
+		t.Errorf("expected empty URL without an object key, got %s", got)
+	}
+}
+
+func TestGetObjectHeadURL(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	headURL := client.GetObjectHeadURL("photo.jpg", time.Hour)
+	if !strings.Contains(headURL, "/objects/photo.jpg?") {
+		t.Errorf("URL should address the object: %s", headURL)
+	}
+	if err := client.VerifyPresignedURL("HEAD", headURL); err != nil {
+		t.Errorf("URL should be signed for HEAD: %v", err)
+	}
+	if err := client.VerifyPresignedURL("GET", headURL); err == nil {
+		t.Error("HEAD URL should not be valid for GET")
+	}
+}
+
 func TestUploadObjectURL(t *testing.T) {
 	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
 
@@ -177,6 +307,27 @@ func TestUploadObjectURL(t *testing.T) {
 	}
 }
 
+func TestUploadObjectURLForContentTypes(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	resultURL := client.UploadObjectURLForContentTypes(time.Hour, "image/png", "image/*")
+	u, _ := url.Parse(resultURL)
+	if got := u.Query()["content-type"]; len(got) != 2 || got[0] != "image/png" || got[1] != "image/*" {
+		t.Errorf("expected both content types, got %v", got)
+	}
+	if err := client.VerifyPresignedURL("POST", resultURL); err != nil {
+		t.Errorf("URL should verify: %v", err)
+	}
+
+	// Dropping the constraint must invalidate the signature
+	query := u.Query()
+	query.Del("content-type")
+	u.RawQuery = query.Encode()
+	if err := client.VerifyPresignedURL("POST", u.String()); err == nil {
+		t.Error("URL without the content type constraint should not verify")
+	}
+}
+
 func TestDeleteObjectURL(t *testing.T) {
 	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
 
@@ -241,6 +392,48 @@ func TestGetPublicObjectURL(t *testing.T) {
 	}
 }
 
+func TestGetPublicObjectURL_Template(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.PublicURLTemplate = "https://cdn.example.com/{projectID}/{bucket}/{filename}"
+
+	expected := fmt.Sprintf("https://cdn.example.com/%s/%s/images/my%%20photo.jpg", testProjectID, testBucketName)
+	if got := client.GetPublicObjectURL("images/my photo.jpg"); got != expected {
+		t.Errorf("public URL mismatch:\nexpected: %s\ngot:      %s", expected, got)
+	}
+}
+
+func TestDisablePublicURLs(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.DisablePublicURLs = true
+
+	if u := client.GetPublicObjectURL("photo.jpg"); u != "" {
+		t.Errorf("expected no public URL, got %s", u)
+	}
+	if _, err := client.PublicObjectURL("photo.jpg"); !errors.Is(err, ErrPublicURLsDisabled) {
+		t.Errorf("expected ErrPublicURLsDisabled, got %v", err)
+	}
+	if _, err := client.DownloadBytes("photo.jpg", &DownloadOptions{UsePublicURL: true}); !errors.Is(err, ErrPublicURLsDisabled) {
+		t.Errorf("public download should fail with ErrPublicURLsDisabled, got %v", err)
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.APIVersion = "v2"
+
+	urls := map[string]string{
+		"GetObjectURL":       client.GetObjectURL("photo.jpg", time.Hour),
+		"UploadObjectURL":    client.UploadObjectURL(time.Hour),
+		"DeleteObjectURL":    client.DeleteObjectURL("photo.jpg", time.Hour),
+		"GetPublicObjectURL": client.GetPublicObjectURL("photo.jpg"),
+	}
+	for name, u := range urls {
+		if !strings.HasPrefix(u, testBaseURL+"/api/v2/") {
+			t.Errorf("%s should use the configured API version: %s", name, u)
+		}
+	}
+}
+
 func TestDefaultOptions(t *testing.T) {
 	opts := DefaultOptions()
 
@@ -274,3 +467,1264 @@ func TestExpiryTime(t *testing.T) {
 		t.Errorf("expires should be ~1 hour from now: got %d, expected between %d and %d", expires, expectedMin, expectedMax)
 	}
 }
+
+func TestGenerateSignatureWithParams(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	method := "GET"
+	path := "/api/v1/projects/uuid/buckets/images/objects/photo.jpg"
+	expires := int64(1735344000)
+
+	// No params must match the plain three-line signature
+	if client.GenerateSignatureWithParams(method, path, expires, nil) != client.GenerateSignature(method, path, expires) {
+		t.Error("signature without params should match GenerateSignature")
+	}
+
+	params := url.Values{
+		"response-content-type":        {"text/plain"},
+		"response-content-disposition": {"inline"},
+	}
+
+	// Manually compute expected signature with canonicalized params
+	stringToSign := fmt.Sprintf("%s\n%s\n%d\n%s", method, path, expires,
+		"response-content-disposition=inline&response-content-type=text%2Fplain")
+	h := hmac.New(sha256.New, []byte(testSecretKey))
+	h.Write([]byte(stringToSign))
+	expected := base64.URLEncoding.EncodeToString(h.Sum(nil))
+
+	actual := client.GenerateSignatureWithParams(method, path, expires, params)
+	if actual != expected {
+		t.Errorf("signature mismatch: expected %s, got %s", expected, actual)
+	}
+
+	// Authentication params must not affect the signature
+	params.Set("X-Mos-AccessKey", "other")
+	if client.GenerateSignatureWithParams(method, path, expires, params) != expected {
+		t.Error("X-Mos-* authentication params should not be signed")
+	}
+}
+
+func TestGeneratePresignedURLWithParams(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	path := "/api/v1/projects/test/buckets/test/objects/test.jpg"
+	params := url.Values{"response-content-disposition": {"inline"}}
+
+	presignedURL := client.GeneratePresignedURLWithParams("GET", path, params, time.Hour)
+
+	parsed, err := url.Parse(presignedURL)
+	if err != nil {
+		t.Fatalf("generated URL should be valid: %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("response-content-disposition") != "inline" {
+		t.Errorf("URL should carry extra params: %s", presignedURL)
+	}
+
+	var expires int64
+	fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+	expected := client.GenerateSignatureWithParams("GET", path, expires, params)
+	if query.Get("X-Mos-Signature") != expected {
+		t.Errorf("signature should cover params: expected %s, got %s", expected, query.Get("X-Mos-Signature"))
+	}
+}
+
+func TestGeneratePresignedURLForIP(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	path := "/api/v1/projects/test/buckets/test/objects/test.jpg"
+
+	presignedURL, err := client.GeneratePresignedURLForIP("GET", path, "203.0.113.7", time.Hour)
+	if err != nil {
+		t.Fatalf("GeneratePresignedURLForIP failed: %v", err)
+	}
+	if !strings.Contains(presignedURL, "X-Mos-SourceIP=203.0.113.7") {
+		t.Errorf("URL should carry the source IP: %s", presignedURL)
+	}
+	if err := client.VerifyPresignedURL("GET", presignedURL); err != nil {
+		t.Errorf("URL should verify: %v", err)
+	}
+
+	tampered := strings.Replace(presignedURL, "203.0.113.7", "198.51.100.1", 1)
+	if err := client.VerifyPresignedURL("GET", tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("changing the source IP should break the signature, got %v", err)
+	}
+
+	if _, err := client.GeneratePresignedURLForIP("GET", path, "not-an-ip", time.Hour); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig for invalid IP, got %v", err)
+	}
+}
+
+func TestPing(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("X-Mos-Signature") == "" {
+			t.Error("ping request should be presigned")
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("ping should succeed: %v", err)
+	}
+
+	status = http.StatusForbidden
+	err := client.Ping(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected APIError with status 403, got %v", err)
+	}
+}
+
+func TestPing_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	err := client.Ping(context.Background())
+	if !errors.Is(err, ErrUnreachable) {
+		t.Errorf("expected ErrUnreachable, got %v", err)
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		t.Error("unreachable error should not match ErrUnauthorized")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if err := client.Validate(); err != nil {
+		t.Errorf("valid client should pass validation: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Client)
+	}{
+		{"empty base URL", func(c *Client) { c.BaseURL = "" }},
+		{"relative base URL", func(c *Client) { c.BaseURL = "storage.example.com" }},
+		{"unsupported scheme", func(c *Client) { c.BaseURL = "ftp://storage.example.com" }},
+		{"empty project ID", func(c *Client) { c.ProjectID = "" }},
+		{"empty bucket name", func(c *Client) { c.BucketName = " " }},
+		{"empty secret key", func(c *Client) { c.SecretKey = "" }},
+		{"access key without prefix", func(c *Client) { c.AccessKey = "UNIT_TEST_FAKE_KEY" }},
+		{"API version with slash", func(c *Client) { c.APIVersion = "v2/objects" }},
+		{"public URL template without filename", func(c *Client) { c.PublicURLTemplate = "https://cdn.example.com/{bucket}" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+			tt.modify(c)
+			if err := c.Validate(); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUploadPut(t *testing.T) {
+	data := []byte("raw binary content")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		expectedPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects/%s", testProjectID, testBucketName, "notes.txt")
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("expected text/plain content type, got %s", ct)
+		}
+		if r.Header.Get("X-Mos-Metadata") != `{"type":"text"}` {
+			t.Errorf("unexpected metadata header: %s", r.Header.Get("X-Mos-Metadata"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != string(data) {
+			t.Errorf("body should be sent as-is, got %q", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"abc","name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	resp, err := client.UploadPut("notes.txt", data, &UploadOptions{
+		Metadata: map[string]interface{}{"type": "text"},
+	})
+	if err != nil {
+		t.Fatalf("upload should succeed: %v", err)
+	}
+	if resp.Name != "abc.txt" {
+		t.Errorf("expected name abc.txt, got %s", resp.Name)
+	}
+}
+
+func TestUpload_Encryption(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("X-Mos-SSE-KeyId")
+		if key != "kms-key-1" {
+			t.Errorf("expected SSE key header, got %q", key)
+		}
+		if r.Method == "PUT" {
+			// Key reported only through the response header
+			w.Header().Set("X-Mos-SSE-KeyId", key)
+			fmt.Fprint(w, `{"name":"abc.txt"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"abc.txt","encryption_key_id":%q}`, key)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	opts := &UploadOptions{EncryptionKeyID: "kms-key-1"}
+
+	resp, err := client.UploadBytes("test.txt", []byte("secret"), opts)
+	if err != nil {
+		t.Fatalf("UploadBytes failed: %v", err)
+	}
+	if resp.EncryptionKeyID != "kms-key-1" {
+		t.Errorf("expected encryption key in response, got %q", resp.EncryptionKeyID)
+	}
+
+	resp, err = client.UploadPut("test.txt", []byte("secret"), opts)
+	if err != nil {
+		t.Fatalf("UploadPut failed: %v", err)
+	}
+	if resp.EncryptionKeyID != "kms-key-1" {
+		t.Errorf("expected encryption key from response header, got %q", resp.EncryptionKeyID)
+	}
+}
+
+func TestUpload_Empty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("empty upload should still have a file part: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		if len(data) != 0 {
+			t.Errorf("expected empty part, got %d bytes", len(data))
+		}
+		fmt.Fprintf(w, `{"name":%q,"size":0}`, header.Filename)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	resp, err := client.UploadBytes("marker", nil, nil)
+	if err != nil {
+		t.Fatalf("UploadBytes with empty content failed: %v", err)
+	}
+	if resp.Stats.Bytes != 0 {
+		t.Errorf("expected 0 bytes sent, got %d", resp.Stats.Bytes)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(filePath, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Upload(filePath, nil); err != nil {
+		t.Fatalf("Upload of empty file failed: %v", err)
+	}
+}
+
+func TestUpload_EmptyRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"file is required"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	_, err := client.UploadBytes("marker", []byte{}, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected wrapped 400 APIError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "empty upload") {
+		t.Errorf("error should mention the empty upload: %v", err)
+	}
+
+	_, err = client.UploadBytes("hello.txt", []byte("hello"), nil)
+	if err == nil || strings.Contains(err.Error(), "empty upload") {
+		t.Errorf("non-empty upload should not be reported as empty: %v", err)
+	}
+}
+
+func TestUpload_CacheControl(t *testing.T) {
+	const cacheControl = "public, max-age=31536000, immutable"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "HEAD" {
+			w.Header().Set("Cache-Control", cacheControl)
+			return
+		}
+		if got := r.Header.Get("X-Mos-Cache-Control"); got != cacheControl {
+			t.Errorf("%s: expected cache control header, got %q", r.Method, got)
+		}
+		if r.Header.Get("Cache-Control") != "" {
+			t.Errorf("%s: the upload request itself should not carry Cache-Control", r.Method)
+		}
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	opts := &UploadOptions{CacheControl: cacheControl}
+
+	if _, err := client.UploadBytes("app.js", []byte("x"), opts); err != nil {
+		t.Fatalf("UploadBytes failed: %v", err)
+	}
+	if _, err := client.UploadPut("app.js", []byte("x"), opts); err != nil {
+		t.Fatalf("UploadPut failed: %v", err)
+	}
+
+	info, err := client.HeadObject("abc.txt")
+	if err != nil {
+		t.Fatalf("HeadObject failed: %v", err)
+	}
+	if info.CacheControl != cacheControl {
+		t.Errorf("expected CacheControl %q, got %q", cacheControl, info.CacheControl)
+	}
+}
+
+func TestUpload_NonJSONSuccess(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Location", "https://storage.example.com/objects/report.pdf")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	// Empty body: success with a response built from the headers
+	body = ""
+	resp, err := client.UploadPut("report.pdf", []byte("data"), nil)
+	if err != nil {
+		t.Fatalf("empty success body should not fail: %v", err)
+	}
+	if resp.Name != "report.pdf" || resp.ETag != `"abc"` || resp.URL == "" || resp.Stats == nil {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	// HTML interstitial: distinct error, response still returned
+	body = "<html><body>Welcome to the hotel wifi</body></html>"
+	resp, err = client.UploadBytes("report.pdf", []byte("data"), &UploadOptions{ObjectKey: "reports/q3.pdf"})
+	if !errors.Is(err, ErrUnparseableResponse) {
+		t.Fatalf("expected ErrUnparseableResponse, got %v", err)
+	}
+	if resp == nil || resp.Name != "reports/q3.pdf" || resp.ETag != `"abc"` {
+		t.Errorf("expected a minimal response, got %+v", resp)
+	}
+}
+
+func TestUpload_Stats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	data := []byte("0123456789")
+
+	for name, upload := range map[string]func() (*FileResponse, error){
+		"UploadBytes": func() (*FileResponse, error) { return client.UploadBytes("test.txt", data, nil) },
+		"UploadPut":   func() (*FileResponse, error) { return client.UploadPut("test.txt", data, nil) },
+	} {
+		resp, err := upload()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		stats := resp.Stats
+		if stats == nil {
+			t.Fatalf("%s: expected stats", name)
+		}
+		if stats.Bytes != int64(len(data)) {
+			t.Errorf("%s: expected %d bytes, got %d", name, len(data), stats.Bytes)
+		}
+		if stats.Duration < 10*time.Millisecond {
+			t.Errorf("%s: duration %s should include the request", name, stats.Duration)
+		}
+		if want := float64(stats.Bytes) / stats.Duration.Seconds(); stats.Throughput != want {
+			t.Errorf("%s: expected throughput %f, got %f", name, want, stats.Throughput)
+		}
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	path := "/api/v1/projects/test/buckets/test/objects/test.jpg"
+	req, _ := http.NewRequest("DELETE", testBaseURL+path+"?versionId=v1", nil)
+
+	if err := client.SignRequest(req, time.Hour); err != nil {
+		t.Fatalf("sign should succeed: %v", err)
+	}
+
+	query := req.URL.Query()
+	if query.Get("X-Mos-AccessKey") != testAccessKey {
+		t.Errorf("X-Mos-AccessKey mismatch: got %s", query.Get("X-Mos-AccessKey"))
+	}
+	if query.Get("versionId") != "v1" {
+		t.Error("existing query params should be preserved")
+	}
+
+	var expires int64
+	fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+	expected := client.GenerateSignatureWithParams("DELETE", path, expires, url.Values{"versionId": {"v1"}})
+	if query.Get("X-Mos-Signature") != expected {
+		t.Errorf("signature mismatch: expected %s, got %s", expected, query.Get("X-Mos-Signature"))
+	}
+
+	if err := client.SignRequest(&http.Request{}, time.Hour); err == nil {
+		t.Error("signing a request without URL should fail")
+	}
+}
+
+func TestObjectURL_EscapesFilename(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	filename := "my photo#1?.jpg"
+	escaped := "my%20photo%231%3F.jpg"
+
+	publicURL := client.GetPublicObjectURL(filename)
+	if !strings.HasSuffix(publicURL, "/"+escaped) {
+		t.Errorf("public URL should escape filename: %s", publicURL)
+	}
+
+	for name, presignedURL := range map[string]string{
+		"GET":    client.GetObjectURL(filename, time.Hour),
+		"DELETE": client.DeleteObjectURL(filename, time.Hour),
+	} {
+		parsed, err := url.Parse(presignedURL)
+		if err != nil {
+			t.Fatalf("%s: generated URL should be valid: %v", name, err)
+		}
+		if !strings.HasSuffix(parsed.EscapedPath(), "/"+escaped) {
+			t.Errorf("%s: URL path should escape filename: %s", name, parsed.EscapedPath())
+		}
+		if parsed.Query().Get("X-Mos-Signature") == "" {
+			t.Errorf("%s: special characters should not leak into the query: %s", name, presignedURL)
+		}
+
+		// The signature must be computed over the path exactly as sent
+		var expires int64
+		fmt.Sscanf(parsed.Query().Get("X-Mos-Expires"), "%d", &expires)
+		expected := client.GenerateSignature(name, parsed.EscapedPath(), expires)
+		if parsed.Query().Get("X-Mos-Signature") != expected {
+			t.Errorf("%s: signature should cover the escaped path", name)
+		}
+	}
+}
+
+func TestObjectURL_NestedKey(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	key := "users/42/my avatar.png"
+	expectedPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects/users/42/my%%20avatar.png", testProjectID, testBucketName)
+
+	for name, presignedURL := range map[string]string{
+		"GET":    client.GetObjectURL(key, time.Hour),
+		"DELETE": client.DeleteObjectURL(key, time.Hour),
+		"PUT":    client.PutObjectURL("/"+key, time.Hour),
+	} {
+		parsed, _ := url.Parse(presignedURL)
+		if parsed.EscapedPath() != expectedPath {
+			t.Errorf("%s: expected path %s, got %s", name, expectedPath, parsed.EscapedPath())
+		}
+	}
+
+	publicURL := client.GetPublicObjectURL(key)
+	if !strings.HasSuffix(publicURL, "/buckets/"+testBucketName+"/users/42/my%20avatar.png") {
+		t.Errorf("public URL should keep key separators: %s", publicURL)
+	}
+}
+
+func TestGetObjectByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/files/abc-123", testProjectID, testBucketName)
+		if r.Method != "GET" || r.URL.Path != expectedPath {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Error("ExtraHeaders should be sent")
+		}
+		fmt.Fprint(w, `{"id":"abc-123","name":"8aabd7f7.jpg"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.ExtraHeaders = map[string]string{"X-Tenant": "acme"}
+
+	obj, err := client.GetObjectByID("abc-123")
+	if err != nil {
+		t.Fatalf("lookup should succeed: %v", err)
+	}
+	if obj.Name != "8aabd7f7.jpg" {
+		t.Errorf("expected name 8aabd7f7.jpg, got %s", obj.Name)
+	}
+}
+
+func TestDeleteByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/files/abc-123", testProjectID, testBucketName)
+		if r.Method != "DELETE" || r.URL.Path != expectedPath {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		expires, _ := strconv.ParseInt(r.URL.Query().Get("X-Mos-Expires"), 10, 64)
+		if remaining := time.Until(time.Unix(expires, 0)); remaining < 50*time.Minute {
+			t.Errorf("delete URL should be valid for about an hour, got %v", remaining)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	if err := client.DeleteByID("abc-123", time.Hour); err != nil {
+		t.Errorf("delete should succeed: %v", err)
+	}
+	if err := client.DeleteByID("abc-123", 0); err != nil {
+		t.Errorf("delete with the default lifetime should succeed: %v", err)
+	}
+}
+
+func TestUpload_MaxSize(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	opts := &UploadOptions{MaxSize: 4}
+
+	if _, err := client.UploadBytes("big.txt", []byte("too large"), opts); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("UploadBytes: expected ErrTooLarge, got %v", err)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "big.txt")
+	os.WriteFile(filePath, []byte("too large"), 0o644)
+	if _, err := client.Upload(filePath, opts); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Upload: expected ErrTooLarge, got %v", err)
+	}
+
+	if requests != 0 {
+		t.Errorf("oversized uploads should not reach the server, got %d requests", requests)
+	}
+
+	if _, err := client.UploadBytes("ok.txt", []byte("tiny"), opts); err != nil {
+		t.Errorf("upload within limit should succeed: %v", err)
+	}
+}
+
+func TestUpload_AllowedContentTypes(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("missing file part: %v", err)
+			return
+		}
+		received, _ = io.ReadAll(file)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	opts := &UploadOptions{AllowedContentTypes: []string{"image/*", "application/pdf"}}
+	png := []byte("\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 600))
+
+	// Disallowed types fail before any request
+	if _, err := client.UploadBytes("script.sh", []byte("#!/bin/sh\nrm -rf /"), opts); !errors.Is(err, ErrDisallowedType) {
+		t.Errorf("UploadBytes: expected ErrDisallowedType, got %v", err)
+	}
+	if _, err := client.UploadPut("page.html", []byte("<html></html>"), opts); !errors.Is(err, ErrDisallowedType) {
+		t.Errorf("UploadPut: expected ErrDisallowedType, got %v", err)
+	}
+	if received != nil {
+		t.Error("disallowed uploads should not reach the server")
+	}
+
+	// Content that does not match its extension is rejected
+	exe := []byte("MZ\x90\x00\x03\x00\x00\x00" + strings.Repeat("\x00", 64))
+	if _, err := client.UploadBytes("evil.png", exe, opts); !errors.Is(err, ErrDisallowedType) {
+		t.Errorf("mislabeled executable: expected ErrDisallowedType, got %v", err)
+	}
+	if _, err := client.UploadBytes("notes.png", []byte("just some text"), opts); !errors.Is(err, ErrDisallowedType) {
+		t.Errorf("mislabeled text: expected ErrDisallowedType, got %v", err)
+	}
+	if received != nil {
+		t.Error("mislabeled uploads should not reach the server")
+	}
+
+	// Formats the sniffer reports as generic zip or binary data are
+	// identified by their extension
+	const docxType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	mime.AddExtensionType(".docx", docxType)
+	mime.AddExtensionType(".heic", "image/heic")
+	docx := []byte("PK\x03\x04\x14\x00\x06\x00" + strings.Repeat("\x00", 64))
+	if _, err := client.UploadBytes("report.docx", docx, &UploadOptions{AllowedContentTypes: []string{docxType}}); err != nil {
+		t.Errorf("docx should be allowed by its extension: %v", err)
+	}
+	heic := []byte("\x00\x00\x00\x18ftypheic" + strings.Repeat("\x00", 64))
+	if _, err := client.UploadBytes("photo.heic", heic, &UploadOptions{AllowedContentTypes: []string{"image/heic"}}); err != nil {
+		t.Errorf("HEIC should be allowed by its extension: %v", err)
+	}
+
+	// Text formats are identified by their extension
+	jsonOpts := &UploadOptions{AllowedContentTypes: []string{"application/json"}}
+	if _, err := client.UploadBytes("data.json", []byte(`{"a":1}`), jsonOpts); err != nil {
+		t.Errorf("JSON file should be allowed: %v", err)
+	}
+
+	// Sniffing is used when the name has no known extension
+	if _, err := client.UploadBytes("upload", png, opts); err != nil {
+		t.Errorf("sniffed image should be allowed: %v", err)
+	}
+
+	// Files are rewound after sniffing
+	filePath := filepath.Join(t.TempDir(), "photo")
+	os.WriteFile(filePath, png, 0o644)
+	if _, err := client.Upload(filePath, opts); err != nil {
+		t.Fatalf("Upload: sniffed image should be allowed: %v", err)
+	}
+	if !bytes.Equal(received, png) {
+		t.Errorf("file should be uploaded in full, got %d of %d bytes", len(received), len(png))
+	}
+}
+
+func TestFileResponse_ObjectKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     FileResponse
+		expected string
+	}{
+		{"from name", FileResponse{Name: "8aabd7f7.jpg", URL: testBaseURL + "/other.jpg"}, "8aabd7f7.jpg"},
+		{"from url", FileResponse{URL: testBaseURL + "/api/v1/public/projects/p/buckets/b/8aabd7f7.jpg"}, "8aabd7f7.jpg"},
+		{"escaped url", FileResponse{URL: testBaseURL + "/api/v1/public/projects/p/buckets/b/my%20file.jpg"}, "my file.jpg"},
+		{"empty", FileResponse{}, ""},
+		{"url without path", FileResponse{URL: testBaseURL}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resp.ObjectKey(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseObjectKeyFromURL(t *testing.T) {
+	const key = "8aabd7f7-1dbf-4ea4-8918-db66069746e7.jpg"
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	valid := []string{
+		client.GetPublicObjectURL(key),
+		client.GetObjectURL(key, time.Hour),
+		testBaseURL + "/8AABD7F7-1DBF-4EA4-8918-DB66069746E7.jpg",
+	}
+	for _, rawurl := range valid {
+		got, err := ParseObjectKeyFromURL(rawurl)
+		if err != nil || !strings.EqualFold(got, key) {
+			t.Errorf("%s: expected %q, got %q (%v)", rawurl, key, got, err)
+		}
+	}
+
+	if got, _ := ParseObjectKeyFromURL(testBaseURL + "/8aabd7f7-1dbf-4ea4-8918-db66069746e7"); got != "8aabd7f7-1dbf-4ea4-8918-db66069746e7" {
+		t.Errorf("key without extension should be accepted, got %q", got)
+	}
+
+	invalid := []string{
+		"",
+		testBaseURL,
+		testBaseURL + "/photo.jpg",
+		testBaseURL + "/8aabd7f7-1dbf-4ea4-8918.jpg",
+		"://bad",
+	}
+	for _, rawurl := range invalid {
+		if _, err := ParseObjectKeyFromURL(rawurl); err == nil {
+			t.Errorf("expected %q to be rejected", rawurl)
+		}
+	}
+}
+
+func TestOperationTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Query().Get("versionId") != "slow" {
+			fmt.Fprint(w, "content")
+			return
+		}
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	_, err := client.UploadBytes("test.txt", []byte("test"), &UploadOptions{Timeout: 50 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("upload: expected context.DeadlineExceeded, got %v", err)
+	}
+
+	_, err = client.DownloadBytes("test.txt", &DownloadOptions{VersionID: "slow", Timeout: 50 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("download: expected context.DeadlineExceeded, got %v", err)
+	}
+
+	data, err := client.DownloadBytes("test.txt", &DownloadOptions{Timeout: time.Second})
+	if err != nil || string(data) != "content" {
+		t.Errorf("fast download within timeout should succeed: %q, %v", data, err)
+	}
+}
+
+func TestDownloadContext_Cancel(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.Write([]byte(strings.Repeat("x", 100)))
+		w.(http.Flusher).Flush()
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	localPath := filepath.Join(t.TempDir(), "data.bin")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := client.DownloadContext(ctx, "data.bin", localPath, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if _, err := os.Stat(localPath); !os.IsNotExist(err) {
+		t.Errorf("partial file should be removed, stat err: %v", err)
+	}
+}
+
+func TestDownloadWithOptions_Conditional(t *testing.T) {
+	etag := `"v1"`
+	lastModified := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Header.Get("If-Modified-Since") == lastModified.Format(http.TimeFormat) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		fmt.Fprint(w, "content")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	localPath := filepath.Join(t.TempDir(), "data.txt")
+
+	result, err := client.DownloadWithOptions("data.txt", localPath, nil)
+	if err != nil {
+		t.Fatalf("download should succeed: %v", err)
+	}
+	if result.ETag != etag {
+		t.Errorf("expected ETag %s, got %s", etag, result.ETag)
+	}
+	if !result.LastModified.Equal(lastModified) {
+		t.Errorf("expected Last-Modified %v, got %v", lastModified, result.LastModified)
+	}
+
+	_, err = client.DownloadWithOptions("data.txt", localPath, &DownloadOptions{IfNoneMatch: result.ETag})
+	if !errors.Is(err, ErrNotModified) {
+		t.Errorf("expected ErrNotModified for matching ETag, got %v", err)
+	}
+
+	_, err = client.DownloadBytes("data.txt", &DownloadOptions{IfModifiedSince: result.LastModified})
+	if !errors.Is(err, ErrNotModified) {
+		t.Errorf("expected ErrNotModified for unchanged object, got %v", err)
+	}
+}
+
+func TestDownload_UsesPresignedURL(t *testing.T) {
+	var requested *url.URL
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL
+		fmt.Fprint(w, "content")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	localPath := filepath.Join(t.TempDir(), "photo.jpg")
+
+	if err := client.Download("photo.jpg", localPath, time.Hour); err != nil {
+		t.Fatalf("download should succeed: %v", err)
+	}
+	expectedPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects/photo.jpg", testProjectID, testBucketName)
+	if requested.Path != expectedPath || requested.Query().Get("X-Mos-Signature") == "" {
+		t.Errorf("download should use a presigned URL, got %s", requested)
+	}
+
+	if _, err := client.DownloadWithOptions("photo.jpg", localPath, &DownloadOptions{UsePublicURL: true}); err != nil {
+		t.Fatalf("download should succeed: %v", err)
+	}
+	expectedPath = fmt.Sprintf("/api/v1/public/projects/%s/buckets/%s/photo.jpg", testProjectID, testBucketName)
+	if requested.Path != expectedPath || requested.Query().Get("X-Mos-Signature") != "" {
+		t.Errorf("UsePublicURL should use the public URL, got %s", requested)
+	}
+}
+
+func TestDownload_HonorsExpiresIn(t *testing.T) {
+	var expires int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Sscanf(r.URL.Query().Get("X-Mos-Expires"), "%d", &expires)
+		fmt.Fprint(w, "content")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	localPath := filepath.Join(t.TempDir(), "photo.jpg")
+
+	for _, expiresIn := range []time.Duration{5 * time.Minute, 0} {
+		expected := expiresIn
+		if expected == 0 {
+			expected = time.Hour
+		}
+
+		before := time.Now().Add(expected).Unix()
+		if err := client.Download("photo.jpg", localPath, expiresIn); err != nil {
+			t.Fatalf("download should succeed: %v", err)
+		}
+		after := time.Now().Add(expected).Unix()
+
+		if expires < before || expires > after {
+			t.Errorf("expiresIn %v: expires should be between %d and %d, got %d", expiresIn, before, after, expires)
+		}
+	}
+}
+
+func TestDownloadResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("X-Mos-Signature") == "" {
+			t.Error("download should use a presigned URL")
+		}
+		if r.URL.Path == fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects/missing.jpg", testProjectID, testBucketName) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		fmt.Fprint(w, "content")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	resp, err := client.DownloadResponse("photo.jpg")
+	if err != nil {
+		t.Fatalf("DownloadResponse failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "42" {
+		t.Error("custom headers should be accessible")
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil || string(data) != "content" {
+		t.Errorf("body should be unread, got %q (%v)", data, err)
+	}
+
+	if _, err := client.DownloadResponse("missing.jpg"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing.ndjson") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "{\"n\":1}\n{\"n\":2}\n")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	r, err := client.Open("events.ndjson")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer r.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines++
+	}
+	if lines != 2 {
+		t.Errorf("expected 2 lines, got %d", lines)
+	}
+
+	var apiErr *APIError
+	if _, err := client.Open("missing.ndjson"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 APIError, got %v", err)
+	}
+}
+
+func TestRefreshURL(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	original := client.GetObjectVersionURL("docs/report 1.pdf", "v42", time.Minute)
+	refreshed, err := client.RefreshURL(original, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("RefreshURL failed: %v", err)
+	}
+
+	u, _ := url.Parse(refreshed)
+	orig, _ := url.Parse(original)
+	if u.EscapedPath() != orig.EscapedPath() {
+		t.Errorf("path changed: %s -> %s", orig.EscapedPath(), u.EscapedPath())
+	}
+
+	query := u.Query()
+	if query.Get("versionId") != "v42" {
+		t.Error("extra parameters should be preserved")
+	}
+	var expires int64
+	fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+	if expires < time.Now().Add(23*time.Hour).Unix() {
+		t.Errorf("expiry should be extended, got %d", expires)
+	}
+	sig := client.GenerateSignatureWithParams("GET", u.EscapedPath(), expires, url.Values{"versionId": {"v42"}})
+	if sig != query.Get("X-Mos-Signature") {
+		t.Error("refreshed URL should carry a valid signature")
+	}
+}
+
+func TestRefreshURL_RejectsForeignRoutes(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	other := NewClient(testBaseURL, testProjectID, "other-bucket", testAccessKey, testSecretKey)
+
+	for _, rawurl := range []string{
+		other.GetObjectURL("photo.jpg", time.Hour),
+		client.GeneratePresignedURL("GET", client.objectsPath(), time.Hour),
+		client.GetPublicObjectURL("photo.jpg"),
+		"://bad",
+	} {
+		if _, err := client.RefreshURL(rawurl, time.Hour); err == nil {
+			t.Errorf("expected %q to be rejected", rawurl)
+		}
+	}
+}
+
+func TestBaseURL_PathPrefix(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/storage/api/v1/") {
+			t.Errorf("request should keep the base path prefix: %s", r.URL.Path)
+		}
+
+		// The server verifies against the path it actually receives
+		verifier := NewClient("", testProjectID, testBucketName, testAccessKey, testSecretKey)
+		query := r.URL.Query()
+		var expires int64
+		fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+		if verifier.GenerateSignature(r.Method, r.URL.EscapedPath(), expires) != query.Get("X-Mos-Signature") {
+			t.Errorf("signature should cover the requested path %s", r.URL.EscapedPath())
+		}
+		fmt.Fprint(w, "content")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/storage", testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if _, err := client.DownloadBytes("photo.jpg", nil); err != nil {
+		t.Fatalf("download should succeed: %v", err)
+	}
+	for _, u := range client.GetObjectURLs([]string{"a.jpg"}, time.Hour) {
+		resp, err := http.Get(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	refreshed, err := client.RefreshURL(client.GetObjectURL("photo.jpg", time.Minute), time.Hour)
+	if err != nil {
+		t.Fatalf("RefreshURL should accept URLs under the base path: %v", err)
+	}
+	if !strings.HasPrefix(refreshed, server.URL+"/storage/api/v1/") {
+		t.Errorf("unexpected refreshed URL %s", refreshed)
+	}
+}
+
+func TestUpload_ObjectKey(t *testing.T) {
+	const key = "sha256/9f86d081884c7d65.txt"
+	objectsPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects", testProjectID, testBucketName)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			query := r.URL.Query()
+			var expires int64
+			fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+			verifier := NewClient("", testProjectID, testBucketName, testAccessKey, testSecretKey)
+			if verifier.GenerateSignatureWithParams("POST", objectsPath, expires, url.Values{"key": {key}}) != query.Get("X-Mos-Signature") {
+				t.Error("object key should be signed into the upload URL")
+			}
+			if r.FormValue("key") != key {
+				t.Errorf("form key = %q, want %q", r.FormValue("key"), key)
+			}
+		case "PUT":
+			if r.URL.EscapedPath() != objectsPath+"/sha256/9f86d081884c7d65.txt" {
+				t.Errorf("PUT should target the object key, got %s", r.URL.EscapedPath())
+			}
+		}
+		json.NewEncoder(w).Encode(FileResponse{Name: key})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if _, err := client.UploadBytes("test.txt", []byte("test"), &UploadOptions{ObjectKey: key}); err != nil {
+		t.Fatalf("UploadBytes failed: %v", err)
+	}
+	if _, err := client.UploadPut("test.txt", []byte("test"), &UploadOptions{ObjectKey: key}); err != nil {
+		t.Fatalf("UploadPut failed: %v", err)
+	}
+}
+
+func TestUpload_ExtensionHint(t *testing.T) {
+	objectsPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects", testProjectID, testBucketName)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			_, header, err := r.FormFile("file")
+			if err != nil || header.Filename != "data.pdf" {
+				t.Errorf("form filename should carry the extension hint, got %v (%v)", header, err)
+			}
+		case "PUT":
+			if r.URL.Path != objectsPath+"/export.csv" {
+				t.Errorf("PUT should target the hinted name, got %s", r.URL.Path)
+			}
+			if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+				t.Errorf("Content-Type should follow the hint, got %s", ct)
+			}
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if _, err := client.UploadBytes("data", []byte("%PDF-1.4"), &UploadOptions{Extension: "pdf"}); err != nil {
+		t.Fatalf("UploadBytes failed: %v", err)
+	}
+	if _, err := client.UploadPut("export.bin", []byte("a,b\n"), &UploadOptions{Extension: ".csv"}); err != nil {
+		t.Fatalf("UploadPut failed: %v", err)
+	}
+}
+
+func TestUpload_VerifyAfterUpload(t *testing.T) {
+	storedSize := "5"
+	heads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "HEAD":
+			heads++
+			if !strings.HasSuffix(r.URL.Path, "/8aabd7f7.txt") {
+				t.Errorf("verification should use the server key, got %s", r.URL.Path)
+			}
+			w.Header().Set("Content-Length", storedSize)
+		default:
+			fmt.Fprint(w, `{"name": "8aabd7f7.txt"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	opts := &UploadOptions{VerifyAfterUpload: true}
+
+	if _, err := client.UploadBytes("a.txt", []byte("hello"), opts); err != nil {
+		t.Errorf("matching size should verify: %v", err)
+	}
+	if _, err := client.UploadPut("a.txt", []byte("hello"), opts); err != nil {
+		t.Errorf("matching size should verify: %v", err)
+	}
+
+	storedSize = "3"
+	resp, err := client.UploadBytes("a.txt", []byte("hello"), opts)
+	if !errors.Is(err, ErrVerifyFailed) {
+		t.Errorf("expected ErrVerifyFailed, got %v", err)
+	}
+	if resp == nil || resp.Name != "8aabd7f7.txt" {
+		t.Error("the upload response should be returned with the verification error")
+	}
+
+	if _, err := client.UploadBytes("a.txt", []byte("hello"), nil); err != nil || heads != 3 {
+		t.Errorf("verification should be off by default (heads=%d, err=%v)", heads, err)
+	}
+}
+
+func TestDownload_FileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permissions")
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "content")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	dir := t.TempDir()
+
+	// A new file gets the mode os.Create would give it under the current umask
+	reference, err := os.Create(filepath.Join(dir, "reference"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference.Close()
+	want, _ := os.Stat(reference.Name())
+
+	newPath := filepath.Join(dir, "new.bin")
+	if _, err := client.DownloadWithOptions("new.bin", newPath, nil); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if info, _ := os.Stat(newPath); info.Mode().Perm() != want.Mode().Perm() {
+		t.Errorf("new file mode = %v, want %v", info.Mode().Perm(), want.Mode().Perm())
+	}
+
+	// A replaced file keeps its permissions
+	existingPath := filepath.Join(dir, "private.bin")
+	if err := os.WriteFile(existingPath, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(existingPath, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DownloadWithOptions("private.bin", existingPath, nil); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if info, _ := os.Stat(existingPath); info.Mode().Perm() != 0o600 {
+		t.Errorf("replaced file mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestDownload_ExpectedSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "0123456789")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	localPath := filepath.Join(t.TempDir(), "file.bin")
+
+	if _, err := client.DownloadWithOptions("file.bin", localPath, &DownloadOptions{ExpectedSize: 10}); err != nil {
+		t.Fatalf("matching size should succeed: %v", err)
+	}
+
+	// A mismatch keeps the previous file and leaves no partial file behind
+	_, err := client.DownloadWithOptions("file.bin", localPath, &DownloadOptions{ExpectedSize: 12})
+	if !errors.Is(err, ErrVerifyFailed) {
+		t.Errorf("expected ErrVerifyFailed, got %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(localPath))
+	if len(entries) != 1 {
+		t.Errorf("temporary file should be removed, found %d entries", len(entries))
+	}
+}
+
+func TestDownload_Truncated(t *testing.T) {
+	const content = "0123456789"
+	newServer := func(supportRange bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			if rng := r.Header.Get("Range"); rng != "" && supportRange {
+				if r.Header.Get("If-Range") != `"v1"` {
+					t.Errorf("resume should be pinned to the ETag, got If-Range %q", r.Header.Get("If-Range"))
+				}
+				var start int
+				fmt.Sscanf(rng, "bytes=%d-", &start)
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+				w.WriteHeader(http.StatusPartialContent)
+				fmt.Fprint(w, content[start:])
+				return
+			}
+			// Announce the full length, then drop the connection
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+			fmt.Fprint(w, content[:4])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}))
+	}
+	localPath := filepath.Join(t.TempDir(), "file.bin")
+
+	// Without retries the truncation is reported
+	server := newServer(true)
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if _, err := client.DownloadWithOptions("file.bin", localPath, nil); !errors.Is(err, ErrIncompleteDownload) {
+		t.Errorf("expected ErrIncompleteDownload, got %v", err)
+	}
+	if _, err := os.Stat(localPath); !os.IsNotExist(err) {
+		t.Errorf("truncated file should be removed, stat err: %v", err)
+	}
+	server.Close()
+
+	// With retries the download resumes from the last byte
+	server = newServer(true)
+	client = NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.MaxRetries = 2
+	client.Backoff = BackoffFunc(func(int) time.Duration { return 0 })
+	result, err := client.DownloadWithOptions("file.bin", localPath, nil)
+	if err != nil {
+		t.Fatalf("download should resume: %v", err)
+	}
+	data, _ := os.ReadFile(localPath)
+	if string(data) != content || result.Size != int64(len(content)) {
+		t.Errorf("expected %q (%d bytes), got %q (%d bytes)", content, len(content), data, result.Size)
+	}
+	server.Close()
+
+	// A server that ignores Range cannot be resumed
+	server = newServer(false)
+	defer server.Close()
+	client = NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.MaxRetries = 2
+	client.Backoff = BackoffFunc(func(int) time.Duration { return 0 })
+	if _, err := client.DownloadWithOptions("file.bin", localPath, nil); !errors.Is(err, ErrIncompleteDownload) {
+		t.Errorf("expected ErrIncompleteDownload, got %v", err)
+	}
+
+	// The failed download leaves the previous complete file untouched
+	data, _ = os.ReadFile(localPath)
+	if string(data) != content {
+		t.Errorf("existing file should be preserved, got %q", data)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(localPath))
+	if len(entries) != 1 {
+		t.Errorf("temporary files should be cleaned up, found %d entries", len(entries))
+	}
+}
@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// copyPart copies r into a form part, gzipping the data when compress is set.
+func copyPart(part io.Writer, r io.Reader, compress bool) error {
+	if !compress {
+		_, err := io.Copy(part, r)
+		return err
+	}
+
+	gz := gzip.NewWriter(part)
+	if _, err := io.Copy(gz, r); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// gzipBytes returns the gzip-compressed form of data.
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := copyPart(&buf, bytes.NewReader(data), true); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// acceptEncoding returns the Accept-Encoding header to send on downloads. An
+// explicit value stops net/http from decompressing on its own, so the body is
+// byte-for-byte what the server stored unless decompression was requested.
+func acceptEncoding(decompress bool) string {
+	if decompress {
+		return "gzip"
+	}
+	return "identity"
+}
+
+// decodeBody wraps the response body in a gzip reader when decompress is set
+// and the server reports Content-Encoding: gzip.
+func decodeBody(resp *http.Response, decompress bool) (io.ReadCloser, error) {
+	if !decompress || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	return gz, nil
+}
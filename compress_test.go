@@ -0,0 +1,142 @@
+package sdk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func gunzip(t *testing.T, r io.Reader) string {
+	t.Helper()
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatalf("body should be gzip-encoded: %v", err)
+	}
+	data, _ := io.ReadAll(gz)
+	return string(data)
+}
+
+func TestUploadBytes_Compress(t *testing.T) {
+	content := `{"log":"line"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("form should contain file: %v", err)
+		}
+		if header.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("file part should declare gzip encoding, got %q", header.Header.Get("Content-Encoding"))
+		}
+		if got := gunzip(t, file); got != content {
+			t.Errorf("expected %q, got %q", content, got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	if _, err := client.UploadBytes("log.json", []byte(content), &UploadOptions{Compress: true}); err != nil {
+		t.Errorf("upload should succeed: %v", err)
+	}
+}
+
+func TestUploadPut_Compress(t *testing.T) {
+	content := "plain text content"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("request should declare gzip encoding, got %q", r.Header.Get("Content-Encoding"))
+		}
+		if got := gunzip(t, r.Body); got != content {
+			t.Errorf("expected %q, got %q", content, got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	if _, err := client.UploadPut("notes.txt", []byte(content), &UploadOptions{Compress: true}); err != nil {
+		t.Errorf("upload should succeed: %v", err)
+	}
+}
+
+func TestDownloadWithOptions_Decompress(t *testing.T) {
+	content := "compressed log line\n"
+	compressed, _ := gzipBytes([]byte(content))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(compressed)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	dir := t.TempDir()
+
+	// Default keeps the stored bytes untouched
+	rawPath := filepath.Join(dir, "raw.gz")
+	if _, err := client.DownloadWithOptions("app.log", rawPath, nil); err != nil {
+		t.Fatalf("download should succeed: %v", err)
+	}
+	raw, _ := os.ReadFile(rawPath)
+	if !bytes.Equal(raw, compressed) {
+		t.Error("download without Decompress should write the compressed bytes as-is")
+	}
+
+	// Decompress yields the original content
+	plainPath := filepath.Join(dir, "app.log")
+	result, err := client.DownloadWithOptions("app.log", plainPath, &DownloadOptions{Decompress: true})
+	if err != nil {
+		t.Fatalf("download should succeed: %v", err)
+	}
+	plain, _ := os.ReadFile(plainPath)
+	if string(plain) != content {
+		t.Errorf("expected %q, got %q", content, plain)
+	}
+	if result.Size != int64(len(content)) {
+		t.Errorf("expected size %d, got %d", len(content), result.Size)
+	}
+	if result.ContentType != "text/plain" {
+		t.Errorf("expected content type text/plain, got %s", result.ContentType)
+	}
+}
+
+func TestDownloadBytes_Decompress(t *testing.T) {
+	content := `{"event":"login"}`
+	compressed, _ := gzipBytes([]byte(content))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	raw, err := client.DownloadBytes("events.json", nil)
+	if err != nil {
+		t.Fatalf("download should succeed: %v", err)
+	}
+	if !bytes.Equal(raw, compressed) {
+		t.Error("download without Decompress should return the compressed bytes as-is")
+	}
+
+	plain, err := client.DownloadBytes("events.json", &DownloadOptions{Decompress: true})
+	if err != nil {
+		t.Fatalf("download should succeed: %v", err)
+	}
+	if string(plain) != content {
+		t.Errorf("expected %q, got %q", content, plain)
+	}
+}
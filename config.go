@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Config is the serializable part of a Client's configuration: endpoint,
+// credentials and the plain-valued options. Transport, retry and metrics hooks
+// are not included and must be set on the client after construction.
+//
+// Secrets are marshaled so a Config can round-trip through a secrets manager.
+// Call Redacted before logging or storing a Config anywhere less trusted.
+type Config struct {
+	BaseURL           string           `json:"base_url"`
+	ProjectID         string           `json:"project_id"`
+	BucketName        string           `json:"bucket_name"`
+	AccessKey         string           `json:"access_key"`
+	SecretKey         string           `json:"secret_key,omitempty"`
+	PreviousSecretKey string           `json:"previous_secret_key,omitempty"`
+	SignatureVersion  SignatureVersion `json:"signature_version,omitempty"`
+	ExpiresFormat     ExpiresFormat    `json:"expires_format,omitempty"`
+	ProxyURL          string           `json:"proxy_url,omitempty"`
+	PublicURLTemplate string           `json:"public_url_template,omitempty"`
+	DisablePublicURLs bool             `json:"disable_public_urls,omitempty"`
+	APIVersion        string           `json:"api_version,omitempty"`
+	MaxRetries        int              `json:"max_retries,omitempty"`
+}
+
+// Redacted returns a copy of cfg with SecretKey and PreviousSecretKey cleared,
+// so they are left out when the copy is marshaled.
+//
+// Example:
+//
+//	data, _ := json.Marshal(client.Config().Redacted())
+//	log.Printf("storage config: %s", data)
+func (cfg Config) Redacted() Config {
+	cfg.SecretKey = ""
+	cfg.PreviousSecretKey = ""
+	return cfg
+}
+
+// NewClientFromConfig creates a client from cfg and validates it. Errors match
+// ErrInvalidConfig.
+//
+// Example:
+//
+//	var cfg sdk.Config
+//	if err := json.Unmarshal(secret, &cfg); err != nil {
+//	    log.Fatal(err)
+//	}
+//	client, err := sdk.NewClientFromConfig(cfg)
+func NewClientFromConfig(cfg Config) (*Client, error) {
+	c := NewClient(cfg.BaseURL, cfg.ProjectID, cfg.BucketName, cfg.AccessKey, cfg.SecretKey)
+	c.PreviousSecretKey = cfg.PreviousSecretKey
+	c.SignatureVersion = cfg.SignatureVersion
+	c.ExpiresFormat = cfg.ExpiresFormat
+	c.ProxyURL = cfg.ProxyURL
+	c.PublicURLTemplate = cfg.PublicURLTemplate
+	c.DisablePublicURLs = cfg.DisablePublicURLs
+	c.APIVersion = cfg.APIVersion
+	c.MaxRetries = cfg.MaxRetries
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Config returns the client's serializable configuration, including secrets.
+//
+// Example:
+//
+//	data, err := json.Marshal(client.Config())
+func (c *Client) Config() Config {
+	return Config{
+		BaseURL:           c.BaseURL,
+		ProjectID:         c.ProjectID,
+		BucketName:        c.BucketName,
+		AccessKey:         c.AccessKey,
+		SecretKey:         c.SecretKey,
+		PreviousSecretKey: c.PreviousSecretKey,
+		SignatureVersion:  c.SignatureVersion,
+		ExpiresFormat:     c.ExpiresFormat,
+		ProxyURL:          c.ProxyURL,
+		PublicURLTemplate: c.PublicURLTemplate,
+		DisablePublicURLs: c.DisablePublicURLs,
+		APIVersion:        c.APIVersion,
+		MaxRetries:        c.MaxRetries,
+	}
+}
+
+// String renders the client's endpoint and credentials with SecretKey and
+// PreviousSecretKey shown as *** and AccessKey partially masked, so a client
+// printed with %v or %+v never leaks secrets into logs.
+func (c *Client) String() string {
+	return fmt.Sprintf("Client{BaseURL: %s, ProjectID: %s, BucketName: %s, AccessKey: %s, SecretKey: %s, PreviousSecretKey: %s}",
+		c.BaseURL, c.ProjectID, c.BucketName, maskAccessKey(c.AccessKey), maskSecret(c.SecretKey), maskSecret(c.PreviousSecretKey))
+}
+
+// GoString is String with Go-syntax quoting, used for %#v.
+func (c *Client) GoString() string {
+	return fmt.Sprintf("&sdk.Client{BaseURL: %q, ProjectID: %q, BucketName: %q, AccessKey: %q, SecretKey: %q, PreviousSecretKey: %q}",
+		c.BaseURL, c.ProjectID, c.BucketName, maskAccessKey(c.AccessKey), maskSecret(c.SecretKey), maskSecret(c.PreviousSecretKey))
+}
+
+// maskSecret hides a secret entirely, keeping only whether it is set.
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "***"
+}
+
+// maskAccessKey keeps the MOS_ prefix and the last four characters of an
+// access key, e.g. "MOS_...5678". Keys too short to mask that way are hidden
+// entirely.
+func maskAccessKey(key string) string {
+	if len(key) <= len(accessKeyPrefix)+8 {
+		return maskSecret(key)
+	}
+	prefix := ""
+	if strings.HasPrefix(key, accessKeyPrefix) {
+		prefix = accessKeyPrefix
+	}
+	return prefix + "..." + key[len(key)-4:]
+}
@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestConfig_RoundTrip(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.SignatureVersion = SignatureV2
+	client.APIVersion = "v2"
+	client.MaxRetries = 3
+
+	data, err := json.Marshal(client.Config())
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	restored, err := NewClientFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	if restored.Config() != client.Config() {
+		t.Errorf("config did not round-trip:\n got  %+v\n want %+v", restored.Config(), client.Config())
+	}
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.PreviousSecretKey = "old_fake_secret"
+
+	data, err := json.Marshal(client.Config().Redacted())
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), testSecretKey) || strings.Contains(string(data), "old_fake_secret") || strings.Contains(string(data), "secret_key") {
+		t.Errorf("redacted config should not contain secrets: %s", data)
+	}
+	if !strings.Contains(string(data), testAccessKey) {
+		t.Errorf("redacted config should keep the access key: %s", data)
+	}
+}
+
+func TestNewClientFromConfig_Invalid(t *testing.T) {
+	_, err := NewClientFromConfig(Config{BaseURL: testBaseURL, ProjectID: testProjectID})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestClient_StringMasksSecrets(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, "MOS_YourAccessKey12345678", testSecretKey)
+	client.PreviousSecretKey = "old_fake_secret"
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		out := fmt.Sprintf(format, client)
+		if strings.Contains(out, testSecretKey) || strings.Contains(out, "old_fake_secret") || strings.Contains(out, "YourAccessKey") {
+			t.Errorf("%s leaks credentials: %s", format, out)
+		}
+		if !strings.Contains(out, "MOS_...5678") || !strings.Contains(out, "***") || !strings.Contains(out, testBucketName) {
+			t.Errorf("%s should show masked credentials and the bucket: %s", format, out)
+		}
+	}
+
+	if got := maskAccessKey("MOS_short"); got != "***" {
+		t.Errorf("short keys should be hidden entirely, got %q", got)
+	}
+}
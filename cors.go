@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSResult reports the server's answer to a CORS preflight request.
+type CORSResult struct {
+	StatusCode       int           // Status of the OPTIONS response
+	AllowOrigin      string        // Access-Control-Allow-Origin
+	AllowMethods     []string      // Access-Control-Allow-Methods
+	AllowHeaders     []string      // Access-Control-Allow-Headers
+	AllowCredentials bool          // Access-Control-Allow-Credentials is "true"
+	MaxAge           time.Duration // Access-Control-Max-Age, zero if absent
+}
+
+// Allowed reports whether a browser at origin would pass the preflight for
+// method: the response is 2xx, the origin is allowed and so is the method.
+func (r *CORSResult) Allowed(origin, method string) bool {
+	if !isSuccess(r.StatusCode) || (r.AllowOrigin != "*" && r.AllowOrigin != origin) {
+		return false
+	}
+	for _, m := range r.AllowMethods {
+		if m == "*" || strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCORS sends the CORS preflight a browser would send before POSTing to
+// rawurl from origin, typically an upload URL from UploadObjectURL, and reports
+// the Access-Control-Allow-* headers returned. It is a diagnostic for browser
+// uploads that fail before the request is even sent. A non-2xx preflight is
+// reported in the result, not as an error.
+//
+// Example:
+//
+//	result, err := client.CheckCORS(client.UploadObjectURL(time.Hour), "https://app.example.com")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if !result.Allowed("https://app.example.com", "POST") {
+//	    log.Printf("preflight rejected: %+v", result)
+//	}
+func (c *Client) CheckCORS(rawurl, origin string) (*CORSResult, error) {
+	return c.CheckCORSMethod(rawurl, origin, "POST")
+}
+
+// CheckCORSMethod is CheckCORS for a request with the given method, e.g. "PUT"
+// for URLs from PutObjectURL.
+func (c *Client) CheckCORSMethod(rawurl, origin, method string) (*CORSResult, error) {
+	req, err := http.NewRequest("OPTIONS", rawurl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	result := &CORSResult{
+		StatusCode:       resp.StatusCode,
+		AllowOrigin:      resp.Header.Get("Access-Control-Allow-Origin"),
+		AllowMethods:     splitHeaderList(resp.Header.Values("Access-Control-Allow-Methods")),
+		AllowHeaders:     splitHeaderList(resp.Header.Values("Access-Control-Allow-Headers")),
+		AllowCredentials: resp.Header.Get("Access-Control-Allow-Credentials") == "true",
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Access-Control-Max-Age")); err == nil && seconds > 0 {
+		result.MaxAge = time.Duration(seconds) * time.Second
+	}
+	return result, nil
+}
+
+// splitHeaderList splits comma-separated header values into trimmed items.
+func splitHeaderList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckCORS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "OPTIONS" {
+			t.Errorf("expected OPTIONS, got %s", r.Method)
+		}
+		if r.Header.Get("Access-Control-Request-Method") != "POST" {
+			t.Errorf("unexpected requested method %q", r.Header.Get("Access-Control-Request-Method"))
+		}
+		if origin := r.Header.Get("Origin"); origin == "https://app.example.com" {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			w.Header().Add("Access-Control-Allow-Methods", "PUT")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			w.Header().Set("Access-Control-Max-Age", "600")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	uploadURL := client.UploadObjectURL(time.Hour)
+
+	result, err := client.CheckCORS(uploadURL, "https://app.example.com")
+	if err != nil {
+		t.Fatalf("CheckCORS failed: %v", err)
+	}
+	if len(result.AllowMethods) != 3 || result.MaxAge != 10*time.Minute || len(result.AllowHeaders) != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if !result.Allowed("https://app.example.com", "post") {
+		t.Error("preflight should allow POST from the configured origin")
+	}
+	if result.Allowed("https://app.example.com", "DELETE") {
+		t.Error("DELETE is not allowed")
+	}
+
+	result, err = client.CheckCORS(uploadURL, "https://evil.example.com")
+	if err != nil {
+		t.Fatalf("CheckCORS failed: %v", err)
+	}
+	if result.Allowed("https://evil.example.com", "POST") {
+		t.Error("unknown origin should not be allowed")
+	}
+}
@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// ContentHash returns the hex-encoded SHA-256 of everything read from r. It
+// is the key SkipIfExists derives for content-addressed uploads (plus the
+// file extension), and can be used to run your own deduplication lookups.
+//
+// Example:
+//
+//	hash, err := sdk.ContentHash(bytes.NewReader(data))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	key := hash + ".png"
+func ContentHash(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// findExisting implements UploadOptions.SkipIfExists. It hashes content,
+// looks up the target key and returns the stored object if it already holds
+// the same content. Otherwise it returns nil and the options to upload with,
+// which carry the derived content key when opts had no ObjectKey. content is
+// rewound before returning.
+func (c *Client) findExisting(filename string, content io.ReadSeeker, opts *UploadOptions) (*FileResponse, *UploadOptions, error) {
+	sha := sha256.New()
+	md := md5.New() // #nosec G401 - compared against the server's ETag, not used for security
+	size, err := io.Copy(io.MultiWriter(sha, md), content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to hash content: %w", err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, fmt.Errorf("failed to rewind content: %w", err)
+	}
+
+	// Copy opts so a derived key does not leak into the caller's options
+	key := opts.ObjectKey
+	if key == "" {
+		derived := *opts
+		derived.ObjectKey = hex.EncodeToString(sha.Sum(nil)) + path.Ext(filename)
+		opts = &derived
+		key = derived.ObjectKey
+	}
+
+	info, err := c.HeadObject(key)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, opts, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to look up existing object: %w", err)
+	}
+
+	// Same rule as DownloadPrefix: sizes match and, when the ETag is an
+	// MD5, so do the hashes
+	etag := strings.Trim(info.ETag, `"`)
+	if info.Size != size || (len(etag) == hex.EncodedLen(md5.Size) && !strings.EqualFold(etag, hex.EncodeToString(md.Sum(nil)))) {
+		return nil, opts, nil
+	}
+
+	return &FileResponse{
+		Name:     key,
+		Size:     info.Size,
+		MimeType: info.ContentType,
+		ETag:     info.ETag,
+		Skipped:  true,
+	}, opts, nil
+}
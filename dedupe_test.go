@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestContentHash(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello"))
+	hash, err := ContentHash(strings.NewReader("hello"))
+	if err != nil || hash != hex.EncodeToString(sum[:]) {
+		t.Errorf("unexpected hash %s (%v)", hash, err)
+	}
+}
+
+func TestUpload_SkipIfExists(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	uploads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == "HEAD" {
+			data, ok := objects[path.Base(r.URL.Path)]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			sum := md5.Sum(data)
+			w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			return
+		}
+
+		uploads++
+		file, _, _ := r.FormFile("file")
+		data, _ := io.ReadAll(file)
+		key := r.FormValue("key")
+		objects[key] = data
+		fmt.Fprintf(w, `{"name":%q}`, key)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	hash, _ := ContentHash(strings.NewReader("logo"))
+
+	// First upload stores under the content key, the second is skipped
+	opts := &UploadOptions{SkipIfExists: true}
+	for i, wantSkipped := range []bool{false, true} {
+		resp, err := client.UploadBytes("logo.png", []byte("logo"), opts)
+		if err != nil {
+			t.Fatalf("upload %d failed: %v", i, err)
+		}
+		if resp.Name != hash+".png" || resp.Skipped != wantSkipped {
+			t.Errorf("upload %d: expected %s.png skipped=%v, got %s skipped=%v", i, hash, wantSkipped, resp.Name, resp.Skipped)
+		}
+	}
+	if opts.ObjectKey != "" {
+		t.Error("the derived key should not be written to the caller's options")
+	}
+
+	// Same size, different content under an explicit key is uploaded
+	objects["fixed.txt"] = []byte("aaaa")
+	resp, err := client.UploadBytes("fixed.txt", []byte("bbbb"), &UploadOptions{SkipIfExists: true, ObjectKey: "fixed.txt"})
+	if err != nil || resp.Skipped {
+		t.Errorf("changed content should be uploaded, got %+v (%v)", resp, err)
+	}
+	if uploads != 2 {
+		t.Errorf("expected 2 uploads, got %d", uploads)
+	}
+}
@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// The default client used by the package-level functions, for scripts and
+// one-off tools. Libraries should create and pass their own Client.
+var (
+	defaultMu     sync.RWMutex
+	defaultClient *Client
+)
+
+// Configure creates a client from cfg and makes it the default used by the
+// package-level Upload, UploadBytes, Download and Delete functions. It may be
+// called again to replace the default; calls in flight keep the client they
+// started with.
+//
+// Example:
+//
+//	if err := sdk.Configure(sdk.Config{
+//	    BaseURL:    "https://storage.miphira.com",
+//	    ProjectID:  projectID,
+//	    BucketName: "images",
+//	    AccessKey:  accessKey,
+//	    SecretKey:  secretKey,
+//	}); err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := sdk.Upload("photo.jpg", nil)
+func Configure(cfg Config) error {
+	c, err := NewClientFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	SetDefaultClient(c)
+	return nil
+}
+
+// SetDefaultClient makes c the default client, or clears it when c is nil.
+func SetDefaultClient(c *Client) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultClient = c
+}
+
+// DefaultClient returns the default client, or nil if none is configured.
+func DefaultClient() *Client {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultClient
+}
+
+// requireDefault returns the default client or an ErrInvalidConfig error.
+func requireDefault() (*Client, error) {
+	c := DefaultClient()
+	if c == nil {
+		return nil, fmt.Errorf("%w: no default client, call sdk.Configure first", ErrInvalidConfig)
+	}
+	return c, nil
+}
+
+// Upload uploads a local file with the default client. See Client.Upload.
+func Upload(filePath string, opts *UploadOptions) (*FileResponse, error) {
+	c, err := requireDefault()
+	if err != nil {
+		return nil, err
+	}
+	return c.Upload(filePath, opts)
+}
+
+// UploadBytes uploads data with the default client. See Client.UploadBytes.
+func UploadBytes(filename string, data []byte, opts *UploadOptions) (*FileResponse, error) {
+	c, err := requireDefault()
+	if err != nil {
+		return nil, err
+	}
+	return c.UploadBytes(filename, data, opts)
+}
+
+// Download downloads an object to localPath with the default client. See
+// Client.Download.
+func Download(filename, localPath string, expiresIn time.Duration) error {
+	c, err := requireDefault()
+	if err != nil {
+		return err
+	}
+	return c.Download(filename, localPath, expiresIn)
+}
+
+// Delete deletes an object with the default client. See Client.Delete.
+func Delete(filename string, expiresIn time.Duration) error {
+	c, err := requireDefault()
+	if err != nil {
+		return err
+	}
+	return c.Delete(filename, expiresIn)
+}
@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDefaultClient(t *testing.T) {
+	defer SetDefaultClient(nil)
+
+	SetDefaultClient(nil)
+	if _, err := UploadBytes("test.txt", []byte("x"), nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig without a default client, got %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			fmt.Fprint(w, `{"name":"abc.txt"}`)
+		case "GET":
+			fmt.Fprint(w, "content")
+		}
+	}))
+	defer server.Close()
+
+	if err := Configure(Config{BaseURL: server.URL, ProjectID: testProjectID, BucketName: testBucketName, AccessKey: testAccessKey}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected invalid config to be rejected, got %v", err)
+	}
+	if err := Configure(Config{BaseURL: server.URL, ProjectID: testProjectID, BucketName: testBucketName, AccessKey: testAccessKey, SecretKey: testSecretKey}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := UploadBytes("test.txt", []byte("x"), nil); err != nil {
+				t.Errorf("UploadBytes failed: %v", err)
+			}
+			if err := Download("abc.txt", filepath.Join(dir, fmt.Sprint(i)), time.Hour); err != nil {
+				t.Errorf("Download failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if data, _ := os.ReadFile(filepath.Join(dir, "0")); string(data) != "content" {
+		t.Errorf("unexpected downloaded content %q", data)
+	}
+}
@@ -0,0 +1,254 @@
+package sdk
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultConcurrency is the number of parallel transfers used by the directory
+// helpers when no limit is configured.
+const defaultConcurrency = 4
+
+// UploadResult reports the outcome of uploading a single file in UploadDir.
+type UploadResult struct {
+	Path string        // Local path of the file
+	Key  string        // Object key the file was uploaded under
+	File *FileResponse // Server response, nil if the upload failed
+	Err  error         // Upload error, nil on success
+}
+
+// UploadDir uploads every regular file below localDir, using the file's path
+// relative to localDir (with forward slashes) prefixed by keyPrefix as its
+// ObjectKey.
+// Uploads run in parallel up to opts.Concurrency. Results are returned in walk
+// order, one per file; the returned error joins all per-file errors, or reports
+// why the directory could not be walked.
+//
+// Example:
+//
+//	results, err := client.UploadDir("./dist", "builds/v1.2.0", &sdk.UploadOptions{
+//	    Concurrency: 8,
+//	})
+//	for _, r := range results {
+//	    if r.Err != nil {
+//	        log.Printf("%s: %v", r.Path, r.Err)
+//	    }
+//	}
+func (c *Client) UploadDir(localDir, keyPrefix string, opts *UploadOptions) ([]UploadResult, error) {
+	// Set defaults before the workers share opts
+	if opts == nil {
+		opts = &UploadOptions{ExpiresIn: time.Hour}
+	}
+	if opts.ExpiresIn == 0 {
+		opts.ExpiresIn = time.Hour
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	var results []UploadResult
+	err := filepath.WalkDir(localDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			if !opts.FollowSymlinks {
+				return nil
+			}
+			info, err := os.Stat(p)
+			if err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+		} else if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(localDir, p)
+		if err != nil {
+			return err
+		}
+		results = append(results, UploadResult{
+			Path: p,
+			Key:  path.Join(keyPrefix, filepath.ToSlash(rel)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(r *UploadResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fileOpts := *opts
+			fileOpts.ObjectKey = r.Key
+			r.File, r.Err = c.Upload(r.Path, &fileOpts)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Path, r.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// DownloadPrefix downloads every object under keyPrefix into localDir,
+// mirroring the remaining part of each key as a relative path and creating
+// intermediate directories. keyPrefix is treated as a directory, so "models"
+// matches "models/x" but not "models2/x". Downloads run in parallel up to
+// opts.Concurrency; with opts.SkipExisting, files that are already up to date
+// are left untouched. Keys that would resolve outside localDir are rejected.
+// Results are returned in listing order, one per object; the returned error
+// joins all per-object errors, or reports why the prefix could not be listed.
+//
+// Example:
+//
+//	results, err := client.DownloadPrefix("models/", "/var/cache/models", &sdk.DownloadOptions{
+//	    SkipExisting: true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, r := range results {
+//	    fmt.Println(r.Key, r.Skipped)
+//	}
+func (c *Client) DownloadPrefix(keyPrefix, localDir string, opts *DownloadOptions) ([]DownloadResult, error) {
+	// Set defaults before the workers share opts
+	if opts == nil {
+		opts = &DownloadOptions{ExpiresIn: time.Hour}
+	}
+	if opts.ExpiresIn == 0 {
+		opts.ExpiresIn = time.Hour
+	}
+	if opts.ExpectedSize != 0 {
+		// A single expected size cannot describe every object
+		o := *opts
+		o.ExpectedSize = 0
+		opts = &o
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	// Match on path boundaries rather than as a raw string prefix
+	if keyPrefix != "" && !strings.HasSuffix(keyPrefix, "/") {
+		keyPrefix += "/"
+	}
+
+	// List all objects under the prefix
+	var objects []FileResponse
+	it := c.IterateObjects(&ListOptions{Prefix: keyPrefix})
+	for obj, ok := it.Next(); ok; obj, ok = it.Next() {
+		objects = append(objects, *obj)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]DownloadResult, len(objects))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range objects {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(obj *FileResponse, r *DownloadResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			c.downloadTo(obj, keyPrefix, localDir, opts, r)
+		}(&objects[i], &results[i])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Key, r.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// downloadTo downloads a listed object below localDir and records the outcome in r.
+func (c *Client) downloadTo(obj *FileResponse, keyPrefix, localDir string, opts *DownloadOptions, r *DownloadResult) {
+	r.Key = obj.ObjectKey()
+
+	if !strings.HasPrefix(r.Key, keyPrefix) {
+		r.Err = fmt.Errorf("object key %q is not under prefix %q", r.Key, keyPrefix)
+		return
+	}
+	rel := filepath.FromSlash(strings.TrimPrefix(strings.TrimPrefix(r.Key, keyPrefix), "/"))
+	if !filepath.IsLocal(rel) {
+		r.Err = fmt.Errorf("object key %q escapes the target directory", r.Key)
+		return
+	}
+	r.Path = filepath.Join(localDir, rel)
+
+	if opts.SkipExisting && isUpToDate(r.Path, obj) {
+		r.Size = obj.Size
+		r.ETag = obj.ETag
+		r.Skipped = true
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0o755); err != nil {
+		r.Err = fmt.Errorf("failed to create directory: %w", err)
+		return
+	}
+
+	result, err := c.DownloadWithOptions(r.Key, r.Path, opts)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	r.Size = result.Size
+	r.ContentType = result.ContentType
+	r.ETag = result.ETag
+	r.LastModified = result.LastModified
+}
+
+// isUpToDate reports whether the file at localPath matches obj's size and, when
+// obj carries an MD5 ETag, its content hash.
+func isUpToDate(localPath string, obj *FileResponse) bool {
+	info, err := os.Stat(localPath)
+	if err != nil || !info.Mode().IsRegular() || info.Size() != obj.Size {
+		return false
+	}
+
+	etag := strings.Trim(obj.ETag, `"`)
+	if len(etag) != hex.EncodedLen(md5.Size) {
+		return true
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	h := md5.New() // #nosec G401 - compared against the server's ETag, not used for security
+	if _, err := io.Copy(h, file); err != nil {
+		return false
+	}
+	return strings.EqualFold(hex.EncodeToString(h.Sum(nil)), etag)
+}
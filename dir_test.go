@@ -0,0 +1,238 @@
+package sdk
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUploadDir(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue("key")
+		if r.URL.Query().Get("key") != name {
+			t.Errorf("form key %q should match the signed key %q", name, r.URL.Query().Get("key"))
+		}
+		mu.Lock()
+		keys = append(keys, name)
+		mu.Unlock()
+		json.NewEncoder(w).Encode(FileResponse{OriginalName: name})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "assets", "css"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"index.html", "assets/app.js", "assets/css/site.css"} {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(dir, "index.html"), filepath.Join(dir, "link.html")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	results, err := client.UploadDir(dir, "builds/v1", &UploadOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("UploadDir failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results (symlink skipped), got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Err != nil || r.File == nil || r.File.OriginalName != r.Key {
+			t.Errorf("unexpected result %+v", r)
+		}
+	}
+
+	sort.Strings(keys)
+	want := []string{"builds/v1/assets/app.js", "builds/v1/assets/css/site.css", "builds/v1/index.html"}
+	for i := range want {
+		if i >= len(keys) || keys[i] != want[i] {
+			t.Fatalf("uploaded keys = %v, want %v", keys, want)
+		}
+	}
+}
+
+func TestUploadDir_FollowSymlinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(FileResponse{})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	results, err := client.UploadDir(dir, "", &UploadOptions{FollowSymlinks: true})
+	if err != nil {
+		t.Fatalf("UploadDir failed: %v", err)
+	}
+	if len(results) != 2 || results[1].Key != "b.txt" {
+		t.Errorf("expected symlinked file to be uploaded, got %+v", results)
+	}
+}
+
+func TestUploadDir_PartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("key") == "bad.txt" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(FileResponse{})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for _, name := range []string{"bad.txt", "good.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	results, err := client.UploadDir(dir, "", nil)
+	if err == nil {
+		t.Fatal("expected an error for the failed file")
+	}
+	if results[0].Err == nil || results[1].Err != nil {
+		t.Errorf("unexpected per-file errors: %v, %v", results[0].Err, results[1].Err)
+	}
+}
+
+func TestDownloadPrefix(t *testing.T) {
+	objectsPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects", testProjectID, testBucketName)
+	contents := map[string]string{
+		"models/v1/weights.bin": "weights",
+		"models/v1/cfg/a.json":  "{}",
+		"models/readme.txt":     "readme",
+	}
+	upToDate := md5.Sum([]byte("readme"))
+
+	var mu sync.Mutex
+	var downloaded []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == objectsPath {
+			if r.URL.Query().Get("prefix") != "models/" {
+				t.Errorf("unexpected prefix %q", r.URL.Query().Get("prefix"))
+			}
+			if r.URL.Query().Get("cursor") == "" {
+				json.NewEncoder(w).Encode(ObjectList{
+					Objects: []FileResponse{
+						{Name: "models/v1/weights.bin", Size: 7},
+						{Name: "models/v1/cfg/a.json", Size: 2},
+					},
+					NextCursor: "next",
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(ObjectList{Objects: []FileResponse{
+				{Name: "models/readme.txt", Size: 6, ETag: `"` + hex.EncodeToString(upToDate[:]) + `"`},
+			}})
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, objectsPath+"/")
+		mu.Lock()
+		downloaded = append(downloaded, key)
+		mu.Unlock()
+		w.Write([]byte(contents[key]))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("readme"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	results, err := client.DownloadPrefix("models/", dir, &DownloadOptions{SkipExisting: true, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("DownloadPrefix failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if !results[2].Skipped {
+		t.Error("up-to-date file should be skipped")
+	}
+	if len(downloaded) != 2 {
+		t.Errorf("expected 2 downloads, got %v", downloaded)
+	}
+
+	for key, want := range contents {
+		got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(key, "models/"))))
+		if err != nil || string(got) != want {
+			t.Errorf("%s: got %q (%v), want %q", key, got, err, want)
+		}
+	}
+}
+
+func TestDownloadPrefix_RejectsTraversal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/objects") {
+			json.NewEncoder(w).Encode(ObjectList{Objects: []FileResponse{{Name: "../escape.txt"}}})
+			return
+		}
+		t.Errorf("object outside the target directory should not be downloaded: %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	results, err := client.DownloadPrefix("", t.TempDir(), nil)
+	if err == nil || results[0].Err == nil {
+		t.Error("expected traversal key to be rejected")
+	}
+}
+
+func TestDownloadPrefix_SiblingPrefix(t *testing.T) {
+	keys := []string{"models/a.bin", "models2/x.bin"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/objects") {
+			// Filter like a server doing plain string prefix matching
+			var list ObjectList
+			for _, key := range keys {
+				if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+					list.Objects = append(list.Objects, FileResponse{Name: key})
+				}
+			}
+			json.NewEncoder(w).Encode(list)
+			return
+		}
+		if strings.Contains(r.URL.Path, "models2") {
+			t.Errorf("sibling prefix should not be downloaded: %s", r.URL.Path)
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	dir := t.TempDir()
+	results, err := client.DownloadPrefix("models", dir, nil)
+	if err != nil {
+		t.Fatalf("DownloadPrefix failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Path != filepath.Join(dir, "a.bin") {
+		t.Errorf("expected only models/a.bin at a.bin, got %+v", results)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2")); !os.IsNotExist(err) {
+		t.Error("a sibling key should not be mapped into the target directory")
+	}
+}
@@ -0,0 +1,76 @@
+package sdk
+
+import (
+	"fmt"
+	"mime"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGetInlineObjectURL(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	u, _ := url.Parse(client.GetInlineObjectURL("report.pdf", time.Hour))
+	query := u.Query()
+	if query.Get("response-content-disposition") != "inline" {
+		t.Errorf("expected inline disposition, got %q", query.Get("response-content-disposition"))
+	}
+
+	var expires int64
+	fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+	params := url.Values{"response-content-disposition": {"inline"}}
+	if client.GenerateSignatureWithParams("GET", u.EscapedPath(), expires, params) != query.Get("X-Mos-Signature") {
+		t.Error("disposition parameter should be signed")
+	}
+}
+
+func TestGetDownloadObjectURL(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	tests := []struct {
+		downloadName string
+		expected     string
+	}{
+		{"Q3 Report.pdf", "Q3 Report.pdf"},
+		{`say "hi".txt`, `say "hi".txt`},
+		{"résumé.pdf", "résumé.pdf"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		u, _ := url.Parse(client.GetDownloadObjectURL("8aabd7f7.pdf", tt.downloadName, time.Hour))
+		disposition, params, err := mime.ParseMediaType(u.Query().Get("response-content-disposition"))
+		if err != nil {
+			t.Errorf("%q: invalid disposition: %v", tt.downloadName, err)
+			continue
+		}
+		if disposition != "attachment" || params["filename"] != tt.expected {
+			t.Errorf("%q: got %s with filename %q", tt.downloadName, disposition, params["filename"])
+		}
+	}
+}
+
+func TestGetObjectURLWithResponseHeaders(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	rawurl := client.GetObjectURLWithResponseHeaders("app.log", ResponseHeaders{ContentType: "text/plain"}, time.Hour)
+	u, _ := url.Parse(rawurl)
+	query := u.Query()
+	if query.Get("response-content-type") != "text/plain" {
+		t.Errorf("expected content type override, got %q", query.Get("response-content-type"))
+	}
+	if query.Has("response-content-disposition") || query.Has("response-cache-control") {
+		t.Errorf("unset overrides should not be sent: %s", rawurl)
+	}
+	if err := client.VerifyPresignedURL("GET", rawurl); err != nil {
+		t.Errorf("URL should verify: %v", err)
+	}
+
+	// Changing the override must invalidate the signature
+	query.Set("response-content-type", "text/html")
+	u.RawQuery = query.Encode()
+	if err := client.VerifyPresignedURL("GET", u.String()); err == nil {
+		t.Error("tampered content type should not verify")
+	}
+}
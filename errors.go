@@ -0,0 +1,178 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrUnreachable is returned when the storage endpoint could not be reached
+	// at all (DNS failure, connection refused, timeout, ...).
+	ErrUnreachable = errors.New("storage endpoint unreachable")
+
+	// ErrUnauthorized matches API errors with status 401 or 403, which usually
+	// indicate a wrong access key, secret key or missing permission.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrInvalidConfig is returned by Client.Validate when the client
+	// configuration is incomplete or malformed.
+	ErrInvalidConfig = errors.New("invalid client configuration")
+
+	// ErrTooLarge is returned before any network call when an upload exceeds
+	// UploadOptions.MaxSize.
+	ErrTooLarge = errors.New("upload exceeds maximum size")
+
+	// ErrRateLimited matches API errors with status 429. The APIError's
+	// RetryAfter field tells how long the server asked the client to wait.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrDisallowedType is returned before any network call when the detected
+	// content type of an upload is not in UploadOptions.AllowedContentTypes.
+	ErrDisallowedType = errors.New("content type not allowed")
+
+	// ErrVerifyFailed is returned when UploadOptions.VerifyAfterUpload finds
+	// that the stored object does not match what was sent, or when a
+	// downloaded file does not have DownloadOptions.ExpectedSize.
+	ErrVerifyFailed = errors.New("verification failed")
+
+	// ErrInvalidSignature is returned by Client.VerifyPresignedURL when a URL
+	// is malformed, expired or not signed with one of the client's secrets.
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrIncompleteDownload is returned when a download ended before the
+	// announced Content-Length was received and could not be resumed.
+	ErrIncompleteDownload = errors.New("incomplete download")
+
+	// ErrPublicURLsDisabled is returned when a public URL is requested from a
+	// client with DisablePublicURLs set.
+	ErrPublicURLsDisabled = errors.New("public URLs are disabled")
+
+	// ErrNotModified matches API errors with status 304, returned by
+	// conditional downloads when the object has not changed.
+	ErrNotModified = errors.New("not modified")
+
+	// ErrPreconditionFailed matches API errors with status 412, returned by
+	// conditional deletes when the object's ETag no longer matches.
+	ErrPreconditionFailed = errors.New("precondition failed")
+
+	// ErrLengthRequired matches API errors with status 411, returned when the
+	// server does not accept chunked uploads. Retry UploadReader with
+	// UploadOptions.SpoolToDisk set.
+	ErrLengthRequired = errors.New("length required")
+
+	// ErrUnparseableResponse is returned, together with a FileResponse
+	// populated from the response headers, when an upload succeeded but the
+	// response body is not the expected JSON (e.g. an HTML page injected by a
+	// proxy). The object was stored; only the server's description is missing.
+	ErrUnparseableResponse = errors.New("upload succeeded but the response could not be parsed")
+
+	// ErrRedirect matches API errors with a 3xx status other than 304,
+	// returned when Client.DisableRedirects is set. The APIError's Location
+	// field holds the redirect target.
+	ErrRedirect = errors.New("redirect not followed")
+)
+
+// APIError is returned when the server responds with an unexpected status code.
+// Use errors.Is with the sentinel errors in this package to classify it.
+//
+// Example:
+//
+//	if errors.Is(err, sdk.ErrUnauthorized) {
+//	    log.Fatal("check your access key and secret key")
+//	}
+type APIError struct {
+	Op         string // Operation that failed (upload, download, delete, ...)
+	StatusCode int    // HTTP status code returned by the server
+	Body       string // Raw response body
+
+	// RetryAfter is the delay requested by the server through the Retry-After
+	// header (429 and 503 responses), or zero if none was given.
+	RetryAfter time.Duration
+
+	// RequestID is the server's X-Request-ID for the failed request, or empty
+	// if none was sent. Include it when reporting problems to the storage team.
+	RequestID string
+
+	// Location is the absolute target of a redirect response that was not
+	// followed, or empty for other responses.
+	Location string
+}
+
+// requestIDHeader carries the server's ID for a request.
+const requestIDHeader = "X-Request-ID"
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s failed with status %d (request ID %s): %s", e.Op, e.StatusCode, e.RequestID, e.Body)
+	}
+	return fmt.Sprintf("%s failed with status %d: %s", e.Op, e.StatusCode, e.Body)
+}
+
+// Is reports whether the API error matches one of the sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrNotModified:
+		return e.StatusCode == http.StatusNotModified
+	case ErrPreconditionFailed:
+		return e.StatusCode == http.StatusPreconditionFailed
+	case ErrLengthRequired:
+		return e.StatusCode == http.StatusLengthRequired
+	case ErrRedirect:
+		return e.StatusCode >= 300 && e.StatusCode <= 399 && e.StatusCode != http.StatusNotModified
+	}
+	return false
+}
+
+// isSuccess reports whether status is a 2xx code. Operations accept any 2xx
+// response rather than one exact code, so the server may answer a delete with
+// 200 or 204 and an upload with 200 or 201.
+func isSuccess(status int) bool {
+	return status >= 200 && status <= 299
+}
+
+// newAPIError builds an APIError from a response, consuming its body.
+func newAPIError(op string, resp *http.Response) *APIError {
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	var location string
+	if loc, err := resp.Location(); err == nil {
+		location = loc.String()
+	}
+	return &APIError{
+		Op:         op,
+		StatusCode: resp.StatusCode,
+		Body:       string(bodyBytes),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		RequestID:  resp.Header.Get(requestIDHeader),
+		Location:   location,
+	}
+}
+
+// parseRetryAfter parses a Retry-After header value given either as a number
+// of seconds or as an HTTP date. It returns zero for missing or invalid values
+// and for dates in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
@@ -0,0 +1,203 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		status   int
+		sentinel error
+		expected bool
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized, true},
+		{http.StatusForbidden, ErrUnauthorized, true},
+		{http.StatusNotFound, ErrUnauthorized, false},
+		{http.StatusTooManyRequests, ErrRateLimited, true},
+		{http.StatusServiceUnavailable, ErrRateLimited, false},
+	}
+
+	for _, tt := range tests {
+		err := error(&APIError{Op: "test", StatusCode: tt.status})
+		if errors.Is(err, tt.sentinel) != tt.expected {
+			t.Errorf("status %d: errors.Is(%v) should be %v", tt.status, tt.sentinel, tt.expected)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"120", 2 * time.Minute},
+		{"-5", 0},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.expected {
+			t.Errorf("parseRetryAfter(%q): expected %v, got %v", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestUpload_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	_, err := client.UploadBytes("hello.txt", []byte("hello"), nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 7*time.Second {
+		t.Errorf("expected RetryAfter of 7s, got %v", err)
+	}
+}
+
+func TestDelete_AcceptsAny2xx(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+		if err := client.Delete("photo.jpg", time.Hour); err != nil {
+			t.Errorf("status %d should be treated as success: %v", status, err)
+		}
+		server.Close()
+	}
+}
+
+func TestDelete_IfMatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") != `"v2"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	err := client.DeleteWithOptions("photo.jpg", &DeleteOptions{IfMatch: `"v1"`})
+	if !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("expected ErrPreconditionFailed, got %v", err)
+	}
+	if err := client.DeleteWithOptions("photo.jpg", &DeleteOptions{IfMatch: `"v2"`}); err != nil {
+		t.Errorf("delete with matching ETag should succeed: %v", err)
+	}
+}
+
+func TestDelete_IgnoreNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	var apiErr *APIError
+	if err := client.Delete("photo.jpg", time.Hour); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a 404 APIError, got %v", err)
+	}
+	if err := client.DeleteWithOptions("photo.jpg", &DeleteOptions{IgnoreNotFound: true}); err != nil {
+		t.Errorf("404 should be ignored with IgnoreNotFound: %v", err)
+	}
+}
+
+func TestDeleteWithResult(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if body == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	tests := []struct {
+		body string
+		want DeleteResult
+	}{
+		{`{"objects_removed":3,"bytes_freed":1048576}`, DeleteResult{ObjectsRemoved: 3, BytesFreed: 1048576}},
+		{"", DeleteResult{}},
+		{"deleted", DeleteResult{}},
+	}
+	for _, tt := range tests {
+		body = tt.body
+		result, err := client.DeleteWithResult("backup.tar", nil)
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", tt.body, err)
+		}
+		if *result != tt.want {
+			t.Errorf("%q: expected %+v, got %+v", tt.body, tt.want, *result)
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-"+r.Method)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	resp, err := client.UploadBytes("hello.txt", []byte("hello"), nil)
+	if err != nil {
+		t.Fatalf("UploadBytes failed: %v", err)
+	}
+	if resp.RequestID != "req-POST" {
+		t.Errorf("expected request ID req-POST, got %q", resp.RequestID)
+	}
+
+	err = client.Delete("abc.txt", time.Hour)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "req-DELETE" {
+		t.Fatalf("expected APIError with request ID req-DELETE, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "req-DELETE") {
+		t.Errorf("error message should include the request ID: %v", err)
+	}
+}
+
+func TestUploadBytes_AcceptsOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name":"abc.txt"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if _, err := client.UploadBytes("hello.txt", []byte("hello"), nil); err != nil {
+		t.Errorf("200 OK should be treated as a successful upload: %v", err)
+	}
+}
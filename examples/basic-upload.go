@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	sdk "github.com/miphira/go-client-sdk"
 )
@@ -35,12 +36,9 @@ func main() {
 	fmt.Println("\n✅ Upload successful!")
 	fmt.Printf("File ID: %s\n", resp.ID)
 	fmt.Printf("Original Name: %s\n", resp.OriginalName)
-	fmt.Printf("Server Filename: %s\n", resp.Name)
+	fmt.Printf("Object Key: %s\n", resp.ObjectKey())
 	fmt.Printf("Size: %s\n", resp.SizeFormatted)
 	fmt.Printf("MIME Type: %s\n", resp.MimeType)
 	fmt.Printf("\n📍 Public URL:\n%s\n", resp.URL)
-	fmt.Println("\n💡 Important:")
-	fmt.Printf("   - Server generated UUID filename: %s\n", resp.Name)
-	fmt.Printf("   - Always use resp.URL or resp.Name for accessing the file\n")
-	fmt.Printf("   - Don't use your original filename (%s) - it won't work!\n", resp.OriginalName)
+	fmt.Printf("\n🔐 Presigned URL (1 hour):\n%s\n", client.GetObjectURL(resp.ObjectKey(), time.Hour))
 }
@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ExpiresFormat selects how the expiry time is encoded in the X-Mos-Expires
+// parameter. The encoded value is also what goes into the string-to-sign, so
+// URL generation and VerifyPresignedURL always agree on it.
+type ExpiresFormat string
+
+const (
+	// ExpiresUnix encodes the expiry as Unix seconds, e.g. "1767225600".
+	ExpiresUnix ExpiresFormat = "unix"
+
+	// ExpiresRFC3339 encodes the expiry as an RFC 3339 UTC timestamp, e.g.
+	// "2026-01-01T00:00:00Z". The server must accept this format before
+	// clients opt in.
+	ExpiresRFC3339 ExpiresFormat = "rfc3339"
+)
+
+// expiresFormat returns the configured expiry format, defaulting to Unix seconds.
+func (c *Client) expiresFormat() ExpiresFormat {
+	if c.ExpiresFormat == "" {
+		return ExpiresUnix
+	}
+	return c.ExpiresFormat
+}
+
+// formatExpires encodes a Unix expiry time in the client's expiry format. It
+// returns an error for formats this SDK does not implement.
+func (c *Client) formatExpires(expires int64) (string, error) {
+	switch format := c.expiresFormat(); format {
+	case ExpiresUnix:
+		return strconv.FormatInt(expires, 10), nil
+	case ExpiresRFC3339:
+		return time.Unix(expires, 0).UTC().Format(time.RFC3339), nil
+	default:
+		return "", fmt.Errorf("unsupported expires format %q", format)
+	}
+}
+
+// ParseExpires decodes an X-Mos-Expires value from a presigned URL into Unix
+// seconds, using the client's ExpiresFormat. Use it to feed DebugSignature.
+//
+// Example:
+//
+//	expires, err := client.ParseExpires(u.Query().Get("X-Mos-Expires"))
+func (c *Client) ParseExpires(value string) (int64, error) {
+	return c.parseExpires(value)
+}
+
+// parseExpires decodes an X-Mos-Expires value in the client's expiry format
+// into Unix seconds.
+func (c *Client) parseExpires(value string) (int64, error) {
+	switch format := c.expiresFormat(); format {
+	case ExpiresUnix:
+		return strconv.ParseInt(value, 10, 64)
+	case ExpiresRFC3339:
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return 0, err
+		}
+		return t.Unix(), nil
+	default:
+		return 0, fmt.Errorf("unsupported expires format %q", format)
+	}
+}
+
+// URLTimeRemaining returns how long a presigned URL stays valid, based on its
+// X-Mos-Expires parameter. The result is negative once the URL has expired.
+// URLs without a parseable expiry return an error.
+//
+// Example:
+//
+//	if remaining, err := client.URLTimeRemaining(cached); err != nil || remaining < time.Minute {
+//	    cached = client.GetObjectURL(filename, time.Hour)
+//	}
+func (c *Client) URLTimeRemaining(rawurl string) (time.Duration, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid URL %q: %w", rawurl, err)
+	}
+	value := u.Query().Get("X-Mos-Expires")
+	if value == "" {
+		return 0, fmt.Errorf("URL %q has no X-Mos-Expires parameter", rawurl)
+	}
+	expires, err := c.parseExpires(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid X-Mos-Expires %q: %w", value, err)
+	}
+	return time.Until(time.Unix(expires, 0)), nil
+}
+
+// IsURLExpired reports whether a presigned URL has expired. URLs whose expiry
+// cannot be determined are reported as expired, so callers regenerate them.
+//
+// Example:
+//
+//	if client.IsURLExpired(cached) {
+//	    cached = client.GetObjectURL(filename, time.Hour)
+//	}
+func (c *Client) IsURLExpired(rawurl string) bool {
+	remaining, err := c.URLTimeRemaining(rawurl)
+	return err != nil || remaining <= 0
+}
@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestURLTimeRemaining(t *testing.T) {
+	for _, format := range []ExpiresFormat{ExpiresUnix, ExpiresRFC3339} {
+		client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+		client.ExpiresFormat = format
+
+		remaining, err := client.URLTimeRemaining(client.GetObjectURL("photo.jpg", time.Hour))
+		if err != nil {
+			t.Fatalf("%s: URLTimeRemaining failed: %v", format, err)
+		}
+		if remaining <= 59*time.Minute || remaining > time.Hour {
+			t.Errorf("%s: expected about an hour, got %s", format, remaining)
+		}
+
+		expired := client.GetObjectURL("photo.jpg", -time.Minute)
+		if remaining, _ := client.URLTimeRemaining(expired); remaining >= 0 {
+			t.Errorf("%s: expired URL should have negative remaining time, got %s", format, remaining)
+		}
+		if !client.IsURLExpired(expired) {
+			t.Errorf("%s: URL should be expired", format)
+		}
+		if client.IsURLExpired(client.GetObjectURL("photo.jpg", time.Hour)) {
+			t.Errorf("%s: fresh URL should not be expired", format)
+		}
+	}
+}
+
+func TestURLTimeRemaining_Invalid(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	for _, rawurl := range []string{
+		client.GetPublicObjectURL("photo.jpg"),
+		testBaseURL + "/photo.jpg?X-Mos-Expires=soon",
+		"://bad",
+	} {
+		if _, err := client.URLTimeRemaining(rawurl); err == nil {
+			t.Errorf("expected error for %q", rawurl)
+		}
+		if !client.IsURLExpired(rawurl) {
+			t.Errorf("%q should be treated as expired", rawurl)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileInfo describes a local file as seen by the upload methods.
+type FileInfo struct {
+	Name        string    // Base name of the file
+	Size        int64     // Size in bytes
+	ContentType string    // Content type the upload methods would detect
+	ModTime     time.Time // Last modification time
+}
+
+// InspectFile returns the size, modification time and content type of a local
+// file. The content type is detected like uploads do: from the extension,
+// falling back to sniffing the first 512 bytes, so it agrees with the MaxSize
+// check and the Content-Type uploads send.
+//
+// Example:
+//
+//	info, err := sdk.InspectFile("photo.jpg")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%s: %d bytes, %s\n", info.Name, info.Size, info.ContentType)
+func InspectFile(path string) (*FileInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if stat.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	name := filepath.Base(path)
+	return &FileInfo{
+		Name:        name,
+		Size:        stat.Size(),
+		ContentType: detectContentType(name, head[:n]),
+		ModTime:     stat.ModTime(),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInspectFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"notes.txt", "hello", "text/plain"},
+		{"image", "\x89PNG\r\n\x1a\n", "image/png"},
+		{"empty", "", "text/plain"},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.name)
+		if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := InspectFile(path)
+		if err != nil {
+			t.Fatalf("%s: InspectFile failed: %v", tt.name, err)
+		}
+		if info.Name != tt.name || info.Size != int64(len(tt.content)) || info.ModTime.IsZero() {
+			t.Errorf("%s: unexpected info %+v", tt.name, info)
+		}
+		if !strings.HasPrefix(info.ContentType, tt.expected) {
+			t.Errorf("%s: content type %q, want %s", tt.name, info.ContentType, tt.expected)
+		}
+	}
+
+	if _, err := InspectFile(dir); err == nil {
+		t.Error("directories should be rejected")
+	}
+	if _, err := InspectFile(filepath.Join(dir, "missing")); err == nil {
+		t.Error("missing files should be rejected")
+	}
+}
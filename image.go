@@ -0,0 +1,46 @@
+package sdk
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ImageOptions selects a server-side image transformation. Zero fields are left
+// to the server (original size, default quality, original format).
+type ImageOptions struct {
+	Width   int    // Target width in pixels
+	Height  int    // Target height in pixels
+	Quality int    // Encoding quality, 1-100
+	Format  string // Output format, e.g. "webp", "jpeg" or "png"
+}
+
+// params returns the signed query parameters for the transformation.
+func (opts ImageOptions) params() url.Values {
+	params := url.Values{}
+	if opts.Width > 0 {
+		params.Set("width", strconv.Itoa(opts.Width))
+	}
+	if opts.Height > 0 {
+		params.Set("height", strconv.Itoa(opts.Height))
+	}
+	if opts.Quality > 0 {
+		params.Set("quality", strconv.Itoa(opts.Quality))
+	}
+	if opts.Format != "" {
+		params.Set("format", opts.Format)
+	}
+	return params
+}
+
+// GetImageURL generates a presigned URL for downloading an image resized or
+// re-encoded on the fly by the server. The transformation parameters are signed,
+// so they cannot be altered.
+//
+// Example:
+//
+//	// 400px wide WebP thumbnail, valid for 1 hour
+//	url := client.GetImageURL("photo.jpg", sdk.ImageOptions{Width: 400, Format: "webp"}, time.Hour)
+func (c *Client) GetImageURL(filename string, opts ImageOptions, expiresIn time.Duration) string {
+	return c.GeneratePresignedURLWithParams("GET", c.objectPath(filename), opts.params(), expiresIn)
+}
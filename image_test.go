@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetImageURL(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	rawURL := client.GetImageURL("photo.jpg", ImageOptions{Width: 400, Height: 300, Quality: 80, Format: "webp"}, time.Hour)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("invalid URL: %v", err)
+	}
+	if !strings.HasSuffix(u.Path, "/objects/photo.jpg") {
+		t.Errorf("unexpected path %s", u.Path)
+	}
+
+	query := u.Query()
+	params := url.Values{"width": {"400"}, "height": {"300"}, "quality": {"80"}, "format": {"webp"}}
+	for name := range params {
+		if query.Get(name) != params.Get(name) {
+			t.Errorf("%s = %q, want %q", name, query.Get(name), params.Get(name))
+		}
+	}
+
+	var expires int64
+	fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+	if sig := client.GenerateSignatureWithParams("GET", u.Path, expires, params); sig != query.Get("X-Mos-Signature") {
+		t.Error("transformation parameters should be signed")
+	}
+}
+
+func TestGetImageURL_OmitsZeroFields(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	u, _ := url.Parse(client.GetImageURL("photo.jpg", ImageOptions{Width: 200}, time.Hour))
+	query := u.Query()
+	if query.Get("width") != "200" {
+		t.Errorf("width = %q, want 200", query.Get("width"))
+	}
+	for _, name := range []string{"height", "quality", "format"} {
+		if query.Has(name) {
+			t.Errorf("%s should be omitted", name)
+		}
+	}
+}
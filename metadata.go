@@ -0,0 +1,32 @@
+package sdk
+
+// metadataUpdate is the request body of UpdateMetadata.
+type metadataUpdate struct {
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+// UpdateMetadata replaces the metadata of an existing object without re-uploading
+// it and returns the updated object. The request is a presigned PATCH on the
+// object path.
+//
+// Example:
+//
+//	resp, err := client.UpdateMetadata("8aabd7f7-1dbf-4ea4-8918-db66069746e7.jpg", map[string]interface{}{
+//	    "category": "profile",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(resp.Metadata["category"])
+func (c *Client) UpdateMetadata(filename string, metadata map[string]interface{}) (*FileResponse, error) {
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+
+	var fileResp FileResponse
+	if err := c.doJSON("PATCH", c.objectPath(filename), nil, metadataUpdate{Metadata: metadata}, &fileResp, "update metadata"); err != nil {
+		return nil, err
+	}
+
+	return &fileResp, nil
+}
@@ -0,0 +1,44 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateMetadata(t *testing.T) {
+	expectedPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects/photo.jpg", testProjectID, testBucketName)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != expectedPath {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		// Signature must cover the PATCH method and object path
+		var expires int64
+		fmt.Sscanf(r.URL.Query().Get("X-Mos-Expires"), "%d", &expires)
+		client := NewClient("", testProjectID, testBucketName, testAccessKey, testSecretKey)
+		if r.URL.Query().Get("X-Mos-Signature") != client.GenerateSignature("PATCH", expectedPath, expires) {
+			t.Error("signature should cover PATCH and the object path")
+		}
+
+		var body metadataUpdate
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		json.NewEncoder(w).Encode(FileResponse{Name: "photo.jpg", Metadata: body.Metadata})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	resp, err := client.UpdateMetadata("photo.jpg", map[string]interface{}{"category": "profile"})
+	if err != nil {
+		t.Fatalf("update should succeed: %v", err)
+	}
+	if resp.Metadata["category"] != "profile" {
+		t.Errorf("unexpected metadata: %v", resp.Metadata)
+	}
+}
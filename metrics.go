@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MetricsCollector receives one observation per API operation, after the
+// response headers arrive or the request fails, including time spent on
+// retries. bytes is the request body size for uploads and the announced
+// response size otherwise (-1 if unknown). err is nil for 2xx responses, an
+// *APIError for other statuses and the transport error for network failures.
+//
+// Implementations must be safe for concurrent use.
+type MetricsCollector interface {
+	ObserveRequest(op string, duration time.Duration, bytes int64, err error)
+}
+
+// NopMetrics is a MetricsCollector that discards all observations. It is used
+// when Client.Metrics is nil.
+type NopMetrics struct{}
+
+// ObserveRequest does nothing.
+func (NopMetrics) ObserveRequest(string, time.Duration, int64, error) {}
+
+// OperationStats aggregates the observations of one operation.
+type OperationStats struct {
+	Count         int64         // Number of requests
+	Errors        int64         // Number of failed requests
+	Bytes         int64         // Total bytes transferred (known sizes only)
+	TotalDuration time.Duration // Sum of request durations
+}
+
+// InMemoryMetrics is a MetricsCollector that aggregates observations per
+// operation in memory, useful in tests and for simple diagnostics.
+//
+// Example:
+//
+//	metrics := &sdk.InMemoryMetrics{}
+//	client.Metrics = metrics
+//	client.Upload("photo.jpg", nil)
+//	fmt.Println(metrics.Snapshot()["upload"].Count)
+type InMemoryMetrics struct {
+	mu  sync.Mutex
+	ops map[string]OperationStats
+}
+
+// ObserveRequest records one observation.
+func (m *InMemoryMetrics) ObserveRequest(op string, duration time.Duration, bytes int64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ops == nil {
+		m.ops = make(map[string]OperationStats)
+	}
+	stats := m.ops[op]
+	stats.Count++
+	if err != nil {
+		stats.Errors++
+	}
+	if bytes > 0 {
+		stats.Bytes += bytes
+	}
+	stats.TotalDuration += duration
+	m.ops[op] = stats
+}
+
+// Snapshot returns a copy of the statistics collected so far, keyed by operation.
+func (m *InMemoryMetrics) Snapshot() map[string]OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]OperationStats, len(m.ops))
+	for op, stats := range m.ops {
+		snapshot[op] = stats
+	}
+	return snapshot
+}
+
+// metrics returns the configured collector or NopMetrics.
+func (c *Client) metrics() MetricsCollector {
+	if c.Metrics != nil {
+		return c.Metrics
+	}
+	return NopMetrics{}
+}
+
+// observe reports the outcome of an operation started at start to the
+// configured collector.
+func (c *Client) observe(op string, start time.Time, req *http.Request, resp *http.Response, err error) {
+	bytes := int64(-1)
+	switch {
+	case req.ContentLength > 0:
+		bytes = req.ContentLength
+	case resp != nil:
+		bytes = resp.ContentLength
+	}
+	if err == nil && !isSuccess(resp.StatusCode) {
+		err = &APIError{Op: op, StatusCode: resp.StatusCode}
+	}
+	c.metrics().ObserveRequest(op, time.Since(start), bytes, err)
+}
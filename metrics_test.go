@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			fmt.Fprint(w, `{}`)
+		case strings.HasSuffix(r.URL.Path, "/missing.jpg"):
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			fmt.Fprint(w, "0123456789")
+		}
+	}))
+	defer server.Close()
+
+	metrics := &InMemoryMetrics{}
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.Metrics = metrics
+
+	if _, err := client.UploadBytes("a.txt", []byte("hello"), nil); err != nil {
+		t.Fatalf("upload failed: %v", err)
+	}
+	if _, err := client.DownloadBytes("a.txt", nil); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	client.DownloadBytes("missing.jpg", nil)
+
+	stats := metrics.Snapshot()
+	if upload := stats["upload"]; upload.Count != 1 || upload.Errors != 0 || upload.Bytes <= 5 {
+		t.Errorf("unexpected upload stats %+v", upload)
+	}
+	if download := stats["download"]; download.Count != 2 || download.Errors != 1 || download.Bytes != 10 {
+		t.Errorf("unexpected download stats %+v", download)
+	}
+}
+
+type recordingMetrics struct {
+	errs []error
+}
+
+func (m *recordingMetrics) ObserveRequest(op string, _ time.Duration, _ int64, err error) {
+	m.errs = append(m.errs, err)
+}
+
+func TestMetrics_ReportsAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.Metrics = metrics
+
+	client.Delete("a.txt", 0)
+	if len(metrics.errs) != 1 || !errors.Is(metrics.errs[0], ErrRateLimited) {
+		t.Errorf("expected one rate-limited observation, got %v", metrics.errs)
+	}
+}
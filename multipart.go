@@ -0,0 +1,202 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// BuildMultipartBody builds the multipart/form-data body used by Upload and
+// UploadBytes: the content of r as the "file" part and, if non-empty, metadata
+// as a JSON "metadata" field. It returns the body and the Content-Type header
+// (including the boundary) to send with it.
+//
+// Use it to send uploads with your own HTTP client or request settings:
+//
+//	body, contentType, err := sdk.BuildMultipartBody("photo.jpg", file, nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	req, _ := http.NewRequest("POST", client.UploadObjectURL(time.Hour), body)
+//	req.Header.Set("Content-Type", contentType)
+func BuildMultipartBody(filename string, r io.Reader, metadata map[string]interface{}) (io.Reader, string, error) {
+	return buildMultipartBody(filename, r, &UploadOptions{Metadata: metadata})
+}
+
+// buildMultipartBody builds the upload form according to opts.
+func buildMultipartBody(filename string, r io.Reader, opts *UploadOptions) (io.Reader, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := writeMultipartBody(writer, filename, r, opts); err != nil {
+		return nil, "", err
+	}
+	return body, writer.FormDataContentType(), nil
+}
+
+// writeMultipartBody writes the upload form for r to writer and closes it.
+func writeMultipartBody(writer *multipart.Writer, filename string, r io.Reader, opts *UploadOptions) error {
+	// Add file
+	part, err := createFormFile(writer, opts.fileFieldName(), filename, opts.Compress)
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if err := copyPart(part, r, opts.Compress); err != nil {
+		return fmt.Errorf("failed to copy file data: %w", err)
+	}
+
+	// Request a specific object key
+	if opts.ObjectKey != "" {
+		if err := writer.WriteField("key", opts.ObjectKey); err != nil {
+			return fmt.Errorf("failed to write key: %w", err)
+		}
+	}
+
+	// Add metadata if provided; an empty map is left out because some
+	// servers reject metadata={} as malformed
+	if len(opts.Metadata) > 0 {
+		if err := writeMetadata(writer, opts); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	return nil
+}
+
+// MetadataEncoding controls how upload metadata is written to the multipart form.
+type MetadataEncoding int
+
+const (
+	// MetadataJSONBlob writes all metadata as a single JSON-encoded field.
+	MetadataJSONBlob MetadataEncoding = iota
+
+	// MetadataFormFields writes each metadata entry as its own form field,
+	// for gateways that expect e.g. x-amz-meta-* fields. Scalar values are
+	// written as text; maps, slices and structs are JSON-encoded.
+	MetadataFormFields
+)
+
+// writeMetadata writes opts.Metadata to the form using opts.MetadataEncoding.
+func writeMetadata(writer *multipart.Writer, opts *UploadOptions) error {
+	switch opts.MetadataEncoding {
+	case MetadataJSONBlob:
+		fieldName := opts.MetadataFieldName
+		if fieldName == "" {
+			fieldName = "metadata"
+		}
+
+		metadataJSON, err := json.Marshal(opts.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		if err := writer.WriteField(fieldName, string(metadataJSON)); err != nil {
+			return fmt.Errorf("failed to write metadata: %w", err)
+		}
+
+	case MetadataFormFields:
+		fieldName, err := metadataFieldNamer(opts.MetadataFieldName)
+		if err != nil {
+			return err
+		}
+
+		// Sort keys so the form is deterministic
+		keys := make([]string, 0, len(opts.Metadata))
+		for k := range opts.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			value, err := formFieldValue(opts.Metadata[k])
+			if err != nil {
+				return fmt.Errorf("failed to marshal metadata %q: %w", k, err)
+			}
+			if err := writer.WriteField(fieldName(k), value); err != nil {
+				return fmt.Errorf("failed to write metadata: %w", err)
+			}
+		}
+
+	default:
+		return fmt.Errorf("unsupported metadata encoding %d", opts.MetadataEncoding)
+	}
+
+	return nil
+}
+
+// metadataFieldNamer returns the function naming the form field of a metadata
+// key for MetadataFormFields. pattern is either a prefix such as "x-amz-meta-"
+// or contains a single %s that is replaced by the key, e.g. "meta[%s]" (the
+// default). Any other % verb is rejected rather than passed to fmt.
+func metadataFieldNamer(pattern string) (func(key string) string, error) {
+	if pattern == "" {
+		pattern = "meta[%s]"
+	}
+	switch {
+	case !strings.Contains(pattern, "%"):
+		return func(key string) string { return pattern + key }, nil
+	case strings.Count(pattern, "%") == 1 && strings.Count(pattern, "%s") == 1:
+		return func(key string) string { return strings.Replace(pattern, "%s", key, 1) }, nil
+	default:
+		return nil, fmt.Errorf("%w: metadata field name %q must be a prefix or contain a single %%s", ErrInvalidConfig, pattern)
+	}
+}
+
+// formFieldValue formats a metadata value for MetadataFormFields. Scalars are
+// written as text, nil (including a nil pointer) as an empty string and
+// complex values as JSON, so a nested map does not end up as Go's map[...]
+// syntax. Pointers are formatted by the value they point to.
+func formFieldValue(v interface{}) (string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "", nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return "", nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		encoded, err := json.Marshal(rv.Interface())
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+	return fmt.Sprint(rv.Interface()), nil
+}
+
+// quoteEscaper escapes quotes and backslashes in multipart header values.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// fileFieldName returns the form field name of the file part.
+func (opts *UploadOptions) fileFieldName() string {
+	if opts.FileFieldName == "" {
+		return "file"
+	}
+	return opts.FileFieldName
+}
+
+// createFormFile creates the file part of an upload form under the field name
+// fieldName. When compress is set the part is marked with Content-Encoding: gzip.
+func createFormFile(writer *multipart.Writer, fieldName, filename string, compress bool) (io.Writer, error) {
+	if !compress {
+		return writer.CreateFormFile(fieldName, filename)
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(fieldName), quoteEscaper.Replace(filename)))
+	h.Set("Content-Type", "application/octet-stream")
+	h.Set("Content-Encoding", "gzip")
+	return writer.CreatePart(h)
+}
@@ -0,0 +1,199 @@
+package sdk
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func TestBuildMultipartBody(t *testing.T) {
+	body, contentType, err := BuildMultipartBody("hello.txt", strings.NewReader("Hello, World!"), map[string]interface{}{"type": "text"})
+	if err != nil {
+		t.Fatalf("build should succeed: %v", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("unexpected content type %q: %v", contentType, err)
+	}
+
+	form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("body should be a valid multipart form: %v", err)
+	}
+
+	files := form.File["file"]
+	if len(files) != 1 || files[0].Filename != "hello.txt" {
+		t.Fatalf("expected one file part named hello.txt, got %v", files)
+	}
+	f, _ := files[0].Open()
+	data, _ := io.ReadAll(f)
+	if string(data) != "Hello, World!" {
+		t.Errorf("unexpected file content %q", data)
+	}
+
+	if got := form.Value["metadata"]; len(got) != 1 || got[0] != `{"type":"text"}` {
+		t.Errorf("unexpected metadata field %v", got)
+	}
+}
+
+func TestBuildMultipartBody_NoMetadata(t *testing.T) {
+	for _, metadata := range []map[string]interface{}{nil, {}} {
+		body, contentType, err := BuildMultipartBody("hello.txt", strings.NewReader("hi"), metadata)
+		if err != nil {
+			t.Fatalf("build should succeed: %v", err)
+		}
+
+		_, params, _ := mime.ParseMediaType(contentType)
+		form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1 << 20)
+		if err != nil {
+			t.Fatalf("body should be a valid multipart form: %v", err)
+		}
+		if _, ok := form.Value["metadata"]; ok {
+			t.Errorf("metadata field should be omitted for %#v", metadata)
+		}
+	}
+}
+
+func parseForm(t *testing.T, body io.Reader, contentType string) *multipart.Form {
+	t.Helper()
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("invalid content type %q: %v", contentType, err)
+	}
+	form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("body should be a valid multipart form: %v", err)
+	}
+	return form
+}
+
+func TestBuildMultipartBody_MetadataFieldName(t *testing.T) {
+	body, contentType, err := buildMultipartBody("hello.txt", strings.NewReader("hi"), &UploadOptions{
+		Metadata:          map[string]interface{}{"type": "text"},
+		MetadataFieldName: "meta",
+	})
+	if err != nil {
+		t.Fatalf("build should succeed: %v", err)
+	}
+
+	form := parseForm(t, body, contentType)
+	if got := form.Value["meta"]; len(got) != 1 || got[0] != `{"type":"text"}` {
+		t.Errorf("unexpected meta field %v", got)
+	}
+	if _, ok := form.Value["metadata"]; ok {
+		t.Error("default metadata field should not be written")
+	}
+}
+
+func TestBuildMultipartBody_MetadataFormFields(t *testing.T) {
+	metadata := map[string]interface{}{"category": "profile", "width": 640}
+
+	body, contentType, err := buildMultipartBody("photo.jpg", strings.NewReader("img"), &UploadOptions{
+		Metadata:          metadata,
+		MetadataEncoding:  MetadataFormFields,
+		MetadataFieldName: "x-amz-meta-%s",
+	})
+	if err != nil {
+		t.Fatalf("build should succeed: %v", err)
+	}
+
+	form := parseForm(t, body, contentType)
+	if got := form.Value["x-amz-meta-category"]; len(got) != 1 || got[0] != "profile" {
+		t.Errorf("unexpected category field %v", got)
+	}
+	if got := form.Value["x-amz-meta-width"]; len(got) != 1 || got[0] != "640" {
+		t.Errorf("unexpected width field %v", got)
+	}
+
+	// A plain prefix is followed by the key
+	body, contentType, err = buildMultipartBody("photo.jpg", strings.NewReader("img"), &UploadOptions{
+		Metadata:          metadata,
+		MetadataEncoding:  MetadataFormFields,
+		MetadataFieldName: "x-amz-meta-",
+	})
+	if err != nil {
+		t.Fatalf("build with a prefix should succeed: %v", err)
+	}
+	form = parseForm(t, body, contentType)
+	if got := form.Value["x-amz-meta-category"]; len(got) != 1 || got[0] != "profile" {
+		t.Errorf("unexpected prefixed field naming: %v", form.Value)
+	}
+
+	// Other format verbs are rejected
+	for _, name := range []string{"meta[%d]", "%s-%s", "x-%v"} {
+		_, _, err := buildMultipartBody("photo.jpg", strings.NewReader("img"), &UploadOptions{
+			Metadata:          metadata,
+			MetadataEncoding:  MetadataFormFields,
+			MetadataFieldName: name,
+		})
+		if !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("%q: expected ErrInvalidConfig, got %v", name, err)
+		}
+	}
+
+	// Default field naming
+	body, contentType, _ = buildMultipartBody("photo.jpg", strings.NewReader("img"), &UploadOptions{
+		Metadata:         metadata,
+		MetadataEncoding: MetadataFormFields,
+	})
+	form = parseForm(t, body, contentType)
+	if got := form.Value["meta[category]"]; len(got) != 1 || got[0] != "profile" {
+		t.Errorf("unexpected default field naming: %v", form.Value)
+	}
+}
+
+func TestBuildMultipartBody_MetadataFormFields_Nested(t *testing.T) {
+	title, width := "Sunset", 640
+	body, contentType, err := buildMultipartBody("photo.jpg", strings.NewReader("img"), &UploadOptions{
+		Metadata: map[string]interface{}{
+			"tags":   []string{"a", "b"},
+			"owner":  map[string]interface{}{"id": 7},
+			"public": true,
+			"note":   nil,
+			"title":  &title,
+			"width":  &width,
+			"author": (*string)(nil),
+		},
+		MetadataEncoding: MetadataFormFields,
+	})
+	if err != nil {
+		t.Fatalf("build should succeed: %v", err)
+	}
+
+	form := parseForm(t, body, contentType)
+	want := map[string]string{
+		"meta[tags]":   `["a","b"]`,
+		"meta[owner]":  `{"id":7}`,
+		"meta[public]": "true",
+		"meta[note]":   "",
+		"meta[title]":  "Sunset",
+		"meta[width]":  "640",
+		"meta[author]": "",
+	}
+	for field, value := range want {
+		if got := form.Value[field]; len(got) != 1 || got[0] != value {
+			t.Errorf("expected %s=%q, got %v", field, value, got)
+		}
+	}
+}
+
+func TestBuildMultipartBody_FileFieldName(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		body, contentType, err := buildMultipartBody("photo.jpg", strings.NewReader("img"), &UploadOptions{
+			FileFieldName: "upload",
+			Compress:      compress,
+		})
+		if err != nil {
+			t.Fatalf("build should succeed: %v", err)
+		}
+
+		form := parseForm(t, body, contentType)
+		if len(form.File["upload"]) != 1 || len(form.File["file"]) != 0 {
+			t.Errorf("compress=%v: expected file part named upload, got %v", compress, form.File)
+		}
+	}
+}
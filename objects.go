@@ -0,0 +1,267 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ListOptions configures ListObjects.
+type ListOptions struct {
+	Prefix string // Only return objects whose key starts with Prefix
+	Limit  int    // Maximum number of objects per page (default: server default)
+	Cursor string // Cursor from a previous ObjectList.NextCursor to continue listing
+}
+
+// ObjectList is one page of objects returned by ListObjects.
+type ObjectList struct {
+	Objects    []FileResponse `json:"objects"`
+	NextCursor string         `json:"next_cursor"` // Empty on the last page
+}
+
+// params returns the signed query parameters for a list request.
+func (opts *ListOptions) params() url.Values {
+	params := url.Values{}
+	if opts.Prefix != "" {
+		params.Set("prefix", opts.Prefix)
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		params.Set("cursor", opts.Cursor)
+	}
+	return params
+}
+
+// ListObjects returns one page of objects in the bucket. Pass the returned
+// NextCursor back in opts.Cursor to fetch the next page.
+//
+// Example:
+//
+//	opts := &sdk.ListOptions{Prefix: "reports/"}
+//	for {
+//	    page, err := client.ListObjects(opts)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    for _, obj := range page.Objects {
+//	        fmt.Println(obj.ObjectKey(), obj.Size)
+//	    }
+//	    if page.NextCursor == "" {
+//	        break
+//	    }
+//	    opts.Cursor = page.NextCursor
+//	}
+func (c *Client) ListObjects(opts *ListOptions) (*ObjectList, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+
+	var result ObjectList
+	if err := c.doJSON("GET", c.objectsPath(), opts.params(), nil, &result, "list objects"); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ObjectIterator walks every object matching a ListOptions, fetching pages
+// with ListObjects as the caller advances. It is not safe for concurrent use.
+type ObjectIterator struct {
+	client *Client
+	opts   ListOptions
+	page   []FileResponse
+	next   int
+	done   bool
+	err    error
+}
+
+// IterateObjects returns an iterator over all objects matching opts, hiding
+// the cursor handling of ListObjects. opts.Cursor, if set, is the starting
+// point. Check Err after Next returns false.
+//
+// Example:
+//
+//	it := client.IterateObjects(&sdk.ListOptions{Prefix: "reports/"})
+//	for obj, ok := it.Next(); ok; obj, ok = it.Next() {
+//	    fmt.Println(obj.ObjectKey(), obj.Size)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) IterateObjects(opts *ListOptions) *ObjectIterator {
+	it := &ObjectIterator{client: c}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
+
+// Next returns the next object, fetching the next page when the current one
+// is exhausted. It returns false when all objects have been returned or a
+// request failed.
+func (it *ObjectIterator) Next() (*FileResponse, bool) {
+	for it.next >= len(it.page) {
+		if it.done || it.err != nil {
+			return nil, false
+		}
+		page, err := it.client.ListObjects(&it.opts)
+		if err != nil {
+			it.err = err
+			return nil, false
+		}
+		it.page, it.next = page.Objects, 0
+		it.opts.Cursor = page.NextCursor
+		it.done = page.NextCursor == ""
+	}
+
+	obj := &it.page[it.next]
+	it.next++
+	return obj, true
+}
+
+// Err returns the error that stopped the iteration, or nil.
+func (it *ObjectIterator) Err() error {
+	return it.err
+}
+
+// ListObjectsByMetadata returns all objects whose metadata matches every
+// entry of filters, e.g. {"category": "gallery"}. opts narrows the listing by
+// Prefix and sets the page size; Cursor is the starting point.
+//
+// The filters are sent to the server's search endpoint as signed
+// metadata[key]=value parameters. If the server does not provide that
+// endpoint (404, 405 or 501), the SDK falls back to listing every object and
+// filtering on the client, comparing values formatted with fmt.Sprint. The
+// fallback costs one list request per page of the whole listing.
+//
+// Example:
+//
+//	objects, err := client.ListObjectsByMetadata(map[string]string{"category": "gallery"}, nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) ListObjectsByMetadata(filters map[string]string, opts *ListOptions) ([]FileResponse, error) {
+	pageOpts := ListOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+
+	matches := []FileResponse{}
+	for first := true; ; first = false {
+		params := pageOpts.params()
+		for k, v := range filters {
+			params.Set("metadata["+k+"]", v)
+		}
+
+		var page ObjectList
+		err := c.doJSON("GET", c.objectsPath()+"/search", params, nil, &page, "search objects")
+		if err != nil {
+			if first && searchUnsupported(err) {
+				return c.filterByMetadata(filters, opts)
+			}
+			return nil, err
+		}
+
+		matches = append(matches, page.Objects...)
+		if page.NextCursor == "" {
+			return matches, nil
+		}
+		pageOpts.Cursor = page.NextCursor
+	}
+}
+
+// searchUnsupported reports whether err means the server has no search
+// endpoint.
+func searchUnsupported(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// filterByMetadata lists all objects matching opts and keeps those whose
+// metadata matches filters.
+func (c *Client) filterByMetadata(filters map[string]string, opts *ListOptions) ([]FileResponse, error) {
+	matches := []FileResponse{}
+	it := c.IterateObjects(opts)
+	for obj, ok := it.Next(); ok; obj, ok = it.Next() {
+		if metadataMatches(obj.Metadata, filters) {
+			matches = append(matches, *obj)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// metadataMatches reports whether metadata has every key of filters with the
+// same value.
+func metadataMatches(metadata map[string]interface{}, filters map[string]string) bool {
+	for k, want := range filters {
+		got, ok := metadata[k]
+		if !ok || fmt.Sprint(got) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// ObjectInfo describes an object as reported by HeadObject.
+type ObjectInfo struct {
+	Size         int64     // Object size in bytes, -1 if unknown
+	ContentType  string    // Content-Type of the object
+	ETag         string    // Entity tag of the object
+	CacheControl string    // Cache-Control stored with the object, empty if none
+	LastModified time.Time // Last modification time, zero if unknown
+}
+
+// HeadObject fetches an object's size, type and ETag with a presigned HEAD
+// request, without downloading its content.
+//
+// Example:
+//
+//	info, err := client.HeadObject("8aabd7f7-1dbf-4ea4-8918-db66069746e7.pdf")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(info.Size, info.ContentType)
+func (c *Client) HeadObject(filename string) (*ObjectInfo, error) {
+	headURL := c.GetObjectHeadURL(filename, DefaultOptions().ExpiresIn)
+
+	// Create request
+	req, err := http.NewRequest("HEAD", headURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Send request
+	resp, err := c.do("head", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to head file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !isSuccess(resp.StatusCode) {
+		return nil, newAPIError("head", resp)
+	}
+
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	return &ObjectInfo{
+		Size:         resp.ContentLength,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ETag:         resp.Header.Get("ETag"),
+		CacheControl: resp.Header.Get("Cache-Control"),
+		LastModified: lastModified,
+	}, nil
+}
@@ -0,0 +1,32 @@
+//go:build go1.23
+
+package sdk
+
+import "iter"
+
+// AllObjects returns a range-over-func sequence of all objects matching opts.
+// A failed page request is yielded once as a nil object with the error, after
+// which the sequence ends. It requires Go 1.23; older toolchains can use
+// IterateObjects.
+//
+// Example:
+//
+//	for obj, err := range client.AllObjects(&sdk.ListOptions{Prefix: "reports/"}) {
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    fmt.Println(obj.ObjectKey())
+//	}
+func (c *Client) AllObjects(opts *ListOptions) iter.Seq2[*FileResponse, error] {
+	return func(yield func(*FileResponse, error) bool) {
+		it := c.IterateObjects(opts)
+		for obj, ok := it.Next(); ok; obj, ok = it.Next() {
+			if !yield(obj, nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
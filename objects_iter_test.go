@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAllObjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			json.NewEncoder(w).Encode(ObjectList{Objects: []FileResponse{{Name: "a"}, {Name: "b"}}, NextCursor: "p2"})
+		case "p2":
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	var names []string
+	var lastErr error
+	for obj, err := range client.AllObjects(nil) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		names = append(names, obj.Name)
+	}
+	if len(names) != 2 || lastErr == nil {
+		t.Errorf("expected 2 objects then an error, got %v and %v", names, lastErr)
+	}
+
+	// Breaking out early stops further page requests
+	for range client.AllObjects(nil) {
+		break
+	}
+}
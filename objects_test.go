@@ -0,0 +1,204 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListObjects(t *testing.T) {
+	objectsPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/objects", testProjectID, testBucketName)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != objectsPath {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		// List parameters must be covered by the signature
+		client := NewClient("", testProjectID, testBucketName, testAccessKey, testSecretKey)
+		query := r.URL.Query()
+		var expires int64
+		fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+		params := map[string][]string{}
+		for _, name := range []string{"prefix", "limit", "cursor"} {
+			if v := query.Get(name); v != "" {
+				params[name] = []string{v}
+			}
+		}
+		if sig := client.GenerateSignatureWithParams("GET", objectsPath, expires, params); sig != query.Get("X-Mos-Signature") {
+			t.Error("list parameters should be signed")
+		}
+
+		switch query.Get("cursor") {
+		case "":
+			if query.Get("prefix") != "reports/" || query.Get("limit") != "2" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(ObjectList{
+				Objects:    []FileResponse{{Name: "reports/a.pdf"}, {Name: "reports/b.pdf"}},
+				NextCursor: "page-2",
+			})
+		case "page-2":
+			json.NewEncoder(w).Encode(ObjectList{Objects: []FileResponse{{Name: "reports/c.pdf"}}})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	opts := &ListOptions{Prefix: "reports/", Limit: 2}
+
+	page, err := client.ListObjects(opts)
+	if err != nil {
+		t.Fatalf("ListObjects failed: %v", err)
+	}
+	if len(page.Objects) != 2 || page.NextCursor != "page-2" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+
+	opts.Cursor = page.NextCursor
+	page, err = client.ListObjects(opts)
+	if err != nil {
+		t.Fatalf("ListObjects failed: %v", err)
+	}
+	if len(page.Objects) != 1 || page.Objects[0].Name != "reports/c.pdf" || page.NextCursor != "" {
+		t.Errorf("unexpected last page: %+v", page)
+	}
+}
+
+func TestObjectIterator(t *testing.T) {
+	failPage := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		if cursor == failPage && cursor != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		switch cursor {
+		case "":
+			json.NewEncoder(w).Encode(ObjectList{Objects: []FileResponse{{Name: "a"}, {Name: "b"}}, NextCursor: "p2"})
+		case "p2":
+			// Empty pages in the middle must not end the iteration
+			json.NewEncoder(w).Encode(ObjectList{NextCursor: "p3"})
+		case "p3":
+			json.NewEncoder(w).Encode(ObjectList{Objects: []FileResponse{{Name: "c"}}})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	var names []string
+	it := client.IterateObjects(nil)
+	for obj, ok := it.Next(); ok; obj, ok = it.Next() {
+		names = append(names, obj.Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+	if strings.Join(names, ",") != "a,b,c" {
+		t.Errorf("expected a,b,c, got %v", names)
+	}
+	if _, ok := it.Next(); ok {
+		t.Error("exhausted iterator should stay exhausted")
+	}
+
+	failPage = "p3"
+	it = client.IterateObjects(nil)
+	count := 0
+	for _, ok := it.Next(); ok; _, ok = it.Next() {
+		count++
+	}
+	if count != 2 || it.Err() == nil {
+		t.Errorf("expected 2 objects then an error, got %d objects and %v", count, it.Err())
+	}
+}
+
+func TestHeadObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "HEAD" {
+			t.Errorf("expected HEAD, got %s", r.Method)
+		}
+		if strings.HasSuffix(r.URL.Path, "/missing.pdf") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Length", "1234")
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	info, err := client.HeadObject("report.pdf")
+	if err != nil {
+		t.Fatalf("HeadObject failed: %v", err)
+	}
+	if info.Size != 1234 || info.ContentType != "application/pdf" || info.ETag != `"abc"` || info.LastModified.IsZero() {
+		t.Errorf("unexpected info %+v", info)
+	}
+
+	if _, err := client.HeadObject("missing.pdf"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestListObjectsByMetadata(t *testing.T) {
+	searchSupported := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/objects/search") {
+			if !searchSupported {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.URL.Query().Get("metadata[category]") != "gallery" {
+				t.Errorf("filter should be sent as a query parameter: %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(ObjectList{Objects: []FileResponse{{Name: "a"}}})
+			return
+		}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			json.NewEncoder(w).Encode(ObjectList{Objects: []FileResponse{
+				{Name: "a", Metadata: map[string]interface{}{"category": "gallery"}},
+				{Name: "b", Metadata: map[string]interface{}{"category": "docs"}},
+			}, NextCursor: "p2"})
+		case "p2":
+			json.NewEncoder(w).Encode(ObjectList{Objects: []FileResponse{
+				{Name: "c", Metadata: map[string]interface{}{"category": "gallery", "rank": 1}},
+				{Name: "d"},
+			}})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	filters := map[string]string{"category": "gallery"}
+
+	objects, err := client.ListObjectsByMetadata(filters, nil)
+	if err != nil || len(objects) != 1 || objects[0].Name != "a" {
+		t.Fatalf("server-side search: unexpected result %v (%v)", objects, err)
+	}
+
+	searchSupported = false
+	objects, err = client.ListObjectsByMetadata(filters, nil)
+	if err != nil {
+		t.Fatalf("fallback failed: %v", err)
+	}
+	var names []string
+	for _, obj := range objects {
+		names = append(names, obj.Name)
+	}
+	if strings.Join(names, ",") != "a,c" {
+		t.Errorf("fallback: expected a,c, got %v", names)
+	}
+
+	objects, _ = client.ListObjectsByMetadata(map[string]string{"category": "gallery", "rank": "1"}, nil)
+	if len(objects) != 1 || objects[0].Name != "c" {
+		t.Errorf("all filters should match, got %v", objects)
+	}
+}
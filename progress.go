@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// ProgressReader wraps an io.Reader and reports the cumulative number of bytes
+// read through it. The upload methods use it to count and report what they
+// send; wrap any reader with it to compose your own pipelines, e.g. hashing
+// and progress around an UploadReader call. BytesRead may be called while
+// another goroutine reads.
+type ProgressReader struct {
+	r          io.Reader
+	n          atomic.Int64
+	onProgress func(read int64)
+}
+
+// NewProgressReader returns a reader that reads from r and calls onProgress
+// (if non-nil) with the total bytes read so far after every read that
+// returned data. onProgress runs on the reading goroutine.
+//
+// Example:
+//
+//	hasher := sha256.New()
+//	body := sdk.NewProgressReader(io.TeeReader(file, hasher), func(read int64) {
+//	    fmt.Printf("\r%d bytes", read)
+//	})
+//	resp, err := client.UploadReader("backup.tar", body, nil)
+func NewProgressReader(r io.Reader, onProgress func(read int64)) *ProgressReader {
+	return &ProgressReader{r: r, onProgress: onProgress}
+}
+
+// Read implements io.Reader.
+func (pr *ProgressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	if n > 0 {
+		read := pr.n.Add(int64(n))
+		if pr.onProgress != nil {
+			pr.onProgress(read)
+		}
+	}
+	return n, err
+}
+
+// BytesRead returns the number of bytes read so far.
+func (pr *ProgressReader) BytesRead() int64 {
+	return pr.n.Load()
+}
+
+// trackProgress reports the bytes of req's body sent to onProgress, along
+// with the body's length (-1 if unknown). A retry starts counting from zero.
+func trackProgress(req *http.Request, onProgress func(sent, total int64)) {
+	if onProgress == nil || req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+
+	total := req.ContentLength
+	if total == 0 {
+		total = -1
+	}
+	wrap := func(body io.ReadCloser) io.ReadCloser {
+		pr := NewProgressReader(body, func(sent int64) { onProgress(sent, total) })
+		return struct {
+			io.Reader
+			io.Closer
+		}{pr, body}
+	}
+
+	req.Body = wrap(req.Body)
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return wrap(body), nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProgressReader(t *testing.T) {
+	var reports []int64
+	pr := NewProgressReader(strings.NewReader("hello world"), func(read int64) {
+		reports = append(reports, read)
+	})
+
+	buf := make([]byte, 4)
+	for {
+		if _, err := pr.Read(buf); err == io.EOF {
+			break
+		}
+	}
+
+	if pr.BytesRead() != 11 {
+		t.Errorf("expected 11 bytes read, got %d", pr.BytesRead())
+	}
+	want := []int64{4, 8, 11}
+	if fmt.Sprint(reports) != fmt.Sprint(want) {
+		t.Errorf("expected reports %v, got %v", want, reports)
+	}
+}
+
+func TestUpload_OnProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	data := []byte(strings.Repeat("x", 100000))
+
+	for name, upload := range map[string]func(*UploadOptions) error{
+		"UploadBytes": func(opts *UploadOptions) error {
+			_, err := client.UploadBytes("test.txt", data, opts)
+			return err
+		},
+		"UploadPut": func(opts *UploadOptions) error {
+			_, err := client.UploadPut("test.txt", data, opts)
+			return err
+		},
+	} {
+		var last, total int64
+		err := upload(&UploadOptions{OnProgress: func(sent, size int64) {
+			if sent < last {
+				t.Errorf("%s: progress went backwards from %d to %d", name, last, sent)
+			}
+			last, total = sent, size
+		}})
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if total < int64(len(data)) || last != total {
+			t.Errorf("%s: expected final progress to reach the total, got %d of %d", name, last, total)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Do sends a presigned request to any API path, for endpoints the SDK has no
+// dedicated method for. A query string in path is signed along with it. The
+// request goes through the client's transport, retries and ExtraHeaders.
+// The caller must close the response body. Non-2xx responses are returned as
+// *APIError with the body already closed.
+//
+// Example:
+//
+//	path := fmt.Sprintf("/api/v1/projects/%s/quota", client.ProjectID)
+//	resp, err := client.Do(ctx, "GET", path, nil, time.Minute)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer resp.Body.Close()
+func (c *Client) Do(ctx context.Context, method, path string, body io.Reader, expiresIn time.Duration) (*http.Response, error) {
+	// Split off and sign any query string
+	var params url.Values
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		query, err := url.ParseQuery(path[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid query in path %q: %w", path, err)
+		}
+		path, params = path[:i], query
+	}
+
+	// Create request
+	requestURL, err := c.presignURL(method, path, params, expiresIn)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	rewindable(req, body)
+
+	// Send request
+	resp, err := c.do("request", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if !isSuccess(resp.StatusCode) {
+		defer resp.Body.Close()
+		return nil, newAPIError("request", resp)
+	}
+
+	return resp, nil
+}
+
+// doJSON sends a presigned request to path with an optional JSON body and
+// decodes a JSON response into out (if non-nil). Non-2xx responses are
+// returned as *APIError tagged with op.
+func (c *Client) doJSON(method, path string, params url.Values, in, out interface{}, op string) error {
+	// Encode request body
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	// Create request
+	requestURL, err := c.presignURL(method, path, params, DefaultOptions().ExpiresIn)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Send request
+	resp, err := c.do(op, req)
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if !isSuccess(resp.StatusCode) {
+		return newAPIError(op, resp)
+	}
+
+	// Parse response
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		if fileResp, ok := out.(*FileResponse); ok {
+			fileResp.RequestID = resp.Header.Get(requestIDHeader)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	var client *Client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := client.VerifyPresignedURL(r.Method, client.BaseURL+r.URL.RequestURI()); err != nil {
+			t.Errorf("request should be presigned: %v", err)
+		}
+		if r.URL.Path != "/api/v1/projects/test/quota" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("period") != "month" {
+			t.Errorf("query should be preserved, got %s", r.URL.RawQuery)
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client = NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	resp, err := client.Do(context.Background(), "POST", "/api/v1/projects/test/quota?period=month", strings.NewReader("payload"), time.Minute)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "payload" {
+		t.Errorf("expected echoed body, got %q", body)
+	}
+
+	_, err = client.Do(context.Background(), "GET", "/api/v1/projects/test/missing", nil, time.Minute)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 APIError, got %v", err)
+	}
+}
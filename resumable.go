@@ -0,0 +1,193 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// defaultPartSize is the part size of resumable uploads when none is given.
+const defaultPartSize = 8 << 20
+
+// UploadCheckpoint is the persisted progress of a resumable upload. It is
+// stored as JSON and rewritten after every completed part.
+type UploadCheckpoint struct {
+	Key      string          `json:"key"`
+	UploadID string          `json:"upload_id"`
+	FilePath string          `json:"file_path"`
+	FileSize int64           `json:"file_size"`
+	PartSize int64           `json:"part_size"`
+	Parts    []CompletedPart `json:"parts"`
+}
+
+// UploadResumable uploads a local file in parts of partSize bytes (default:
+// 8 MiB) under key, recording progress in the file at checkpointPath after
+// every part. If the process dies, calling UploadResumable again with the same
+// checkpoint, or ResumeUpload, continues after the last completed part. An
+// existing checkpoint for a different file or key is an error rather than
+// being resumed. The checkpoint file is removed once the upload completes.
+//
+// Example:
+//
+//	resp, err := client.UploadResumable("recording.mp4", "recordings/2024-06-01.mp4",
+//	    "recording.mp4.upload", 0)
+//	if err != nil {
+//	    log.Fatal(err) // run again to resume
+//	}
+func (c *Client) UploadResumable(filePath, key, checkpointPath string, partSize int64) (*FileResponse, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve file path: %w", err)
+	}
+
+	// Only resume a checkpoint that belongs to this upload
+	if _, err := os.Stat(checkpointPath); err == nil {
+		checkpoint, err := LoadUploadCheckpoint(checkpointPath)
+		if err != nil {
+			return nil, err
+		}
+		if checkpoint.FilePath != absPath || checkpoint.Key != key {
+			return nil, fmt.Errorf("checkpoint %s belongs to the upload of %s as %q, not %s as %q",
+				checkpointPath, checkpoint.FilePath, checkpoint.Key, absPath, key)
+		}
+		return c.ResumeUpload(checkpointPath)
+	}
+
+	if partSize <= 0 {
+		partSize = defaultPartSize
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	upload, err := c.CreateMultipartUpload(key)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &UploadCheckpoint{
+		Key:      upload.Key,
+		UploadID: upload.UploadID,
+		FilePath: absPath,
+		FileSize: info.Size(),
+		PartSize: partSize,
+	}
+	if err := checkpoint.save(checkpointPath); err != nil {
+		return nil, err
+	}
+
+	return c.continueUpload(upload, checkpoint, checkpointPath)
+}
+
+// ResumeUpload continues a resumable upload from the checkpoint file written by
+// UploadResumable, uploading only the parts that are not recorded as complete.
+// It fails if the source file changed size since the upload started.
+//
+// Example:
+//
+//	resp, err := client.ResumeUpload("recording.mp4.upload")
+func (c *Client) ResumeUpload(checkpointPath string) (*FileResponse, error) {
+	checkpoint, err := LoadUploadCheckpoint(checkpointPath)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(checkpoint.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.Size() != checkpoint.FileSize {
+		return nil, fmt.Errorf("cannot resume upload %s: %s changed size from %d to %d bytes",
+			checkpoint.UploadID, checkpoint.FilePath, checkpoint.FileSize, info.Size())
+	}
+
+	upload := &MultipartUpload{
+		Key:      checkpoint.Key,
+		UploadID: checkpoint.UploadID,
+		client:   c,
+		parts:    make(map[int]CompletedPart, len(checkpoint.Parts)),
+	}
+	for _, part := range checkpoint.Parts {
+		upload.parts[part.Number] = part
+	}
+
+	return c.continueUpload(upload, checkpoint, checkpointPath)
+}
+
+// LoadUploadCheckpoint reads a checkpoint file written by UploadResumable.
+func LoadUploadCheckpoint(checkpointPath string) (*UploadCheckpoint, error) {
+	data, err := os.ReadFile(checkpointPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var checkpoint UploadCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	if checkpoint.UploadID == "" || checkpoint.PartSize <= 0 {
+		return nil, fmt.Errorf("invalid checkpoint %s", checkpointPath)
+	}
+	return &checkpoint, nil
+}
+
+// save atomically replaces the checkpoint file, so a crash mid-write never
+// leaves a truncated checkpoint behind.
+func (cp *UploadCheckpoint) save(checkpointPath string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	tmp := checkpointPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, checkpointPath); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// continueUpload uploads the parts missing from upload in order, saving the
+// checkpoint after each one, then completes the upload and removes the checkpoint.
+func (c *Client) continueUpload(upload *MultipartUpload, checkpoint *UploadCheckpoint, checkpointPath string) (*FileResponse, error) {
+	file, err := os.Open(checkpoint.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	buf := make([]byte, checkpoint.PartSize)
+	for number, offset := 1, int64(0); offset < checkpoint.FileSize || number == 1; number, offset = number+1, offset+checkpoint.PartSize {
+		if _, done := upload.parts[number]; done {
+			continue
+		}
+
+		n, err := file.ReadAt(buf, offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read part %d: %w", number, err)
+		}
+		if _, err := upload.UploadPart(number, bytes.NewReader(buf[:n])); err != nil {
+			return nil, err
+		}
+
+		checkpoint.Parts = upload.CompletedParts()
+		if err := checkpoint.save(checkpointPath); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := upload.Complete()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(checkpointPath); err != nil {
+		return nil, fmt.Errorf("upload completed but the checkpoint could not be removed: %w", err)
+	}
+	return resp, nil
+}
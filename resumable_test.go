@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUploadResumable(t *testing.T) {
+	server, fake := newMultipartServer(t)
+	defer server.Close()
+	fake.fail["2"] = true
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "recording.bin")
+	checkpointPath := filepath.Join(dir, "recording.bin.upload")
+	content := strings.Repeat("a", 10) + strings.Repeat("b", 10) + strings.Repeat("c", 5)
+	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	// First attempt fails on part 2 and leaves a checkpoint behind
+	if _, err := client.UploadResumable(filePath, "recording.bin", checkpointPath, 10); err == nil {
+		t.Fatal("expected the first attempt to fail")
+	}
+	checkpoint, err := LoadUploadCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatalf("checkpoint should be readable: %v", err)
+	}
+	if checkpoint.UploadID != "up-1" || len(checkpoint.Parts) != 1 || checkpoint.Parts[0].Number != 1 {
+		t.Fatalf("unexpected checkpoint %+v", checkpoint)
+	}
+
+	// Resuming uploads only the missing parts
+	fake.fail["2"] = false
+	resp, err := client.ResumeUpload(checkpointPath)
+	if err != nil {
+		t.Fatalf("ResumeUpload failed: %v", err)
+	}
+	if resp.Size != int64(len(content)) {
+		t.Errorf("expected %d assembled bytes, got %d", len(content), resp.Size)
+	}
+	if fake.puts["1"] != 1 || fake.puts["2"] != 2 || fake.puts["3"] != 1 {
+		t.Errorf("completed parts should not be re-uploaded: %v", fake.puts)
+	}
+	if fake.parts["3"] != "ccccc" {
+		t.Errorf("last part should be short, got %q", fake.parts["3"])
+	}
+	if _, err := os.Stat(checkpointPath); !os.IsNotExist(err) {
+		t.Error("checkpoint should be removed after completion")
+	}
+}
+
+func TestResumeUpload_FileChanged(t *testing.T) {
+	server, fake := newMultipartServer(t)
+	defer server.Close()
+	fake.fail["1"] = true
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "recording.bin")
+	checkpointPath := filepath.Join(dir, "recording.bin.upload")
+	if err := os.WriteFile(filePath, []byte("original"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if _, err := client.UploadResumable(filePath, "recording.bin", checkpointPath, 0); err == nil {
+		t.Fatal("expected the first attempt to fail")
+	}
+
+	if err := os.WriteFile(filePath, []byte("modified content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ResumeUpload(checkpointPath); err == nil {
+		t.Error("resume should fail when the source file changed")
+	}
+}
+
+func TestUploadResumable_OtherCheckpoint(t *testing.T) {
+	server, fake := newMultipartServer(t)
+	defer server.Close()
+	fake.fail["1"] = true
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "recording.bin")
+	otherPath := filepath.Join(dir, "other.bin")
+	checkpointPath := filepath.Join(dir, "upload.checkpoint")
+	for _, path := range []string{filePath, otherPath} {
+		if err := os.WriteFile(path, []byte("content"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if _, err := client.UploadResumable(filePath, "recording.bin", checkpointPath, 0); err == nil {
+		t.Fatal("expected the first attempt to fail")
+	}
+	delete(fake.fail, "1")
+
+	// Reusing the checkpoint path for another file or key must not finish
+	// the first upload
+	if _, err := client.UploadResumable(otherPath, "recording.bin", checkpointPath, 0); err == nil {
+		t.Error("a checkpoint for another file should be rejected")
+	}
+	if _, err := client.UploadResumable(filePath, "other.bin", checkpointPath, 0); err == nil {
+		t.Error("a checkpoint for another key should be rejected")
+	}
+	if _, err := os.Stat(checkpointPath); err != nil {
+		t.Errorf("the checkpoint should be kept: %v", err)
+	}
+
+	if _, err := client.UploadResumable(filePath, "recording.bin", checkpointPath, 0); err != nil {
+		t.Errorf("the matching upload should resume: %v", err)
+	}
+}
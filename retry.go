@@ -0,0 +1,174 @@
+package sdk
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Backoff decides how long to wait before retrying a failed request. attempt
+// is zero for the first retry and increases by one for each further retry.
+type Backoff interface {
+	Next(attempt int) time.Duration
+}
+
+// BackoffFunc adapts an ordinary function to the Backoff interface.
+//
+// Example:
+//
+//	// Retry immediately, e.g. in tests
+//	client.Backoff = sdk.BackoffFunc(func(int) time.Duration { return 0 })
+type BackoffFunc func(attempt int) time.Duration
+
+// Next calls f(attempt).
+func (f BackoffFunc) Next(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// ExponentialBackoff is an exponential backoff with full jitter: the delay
+// before retry n is chosen uniformly from [0, min(Max, Base*2^n)].
+type ExponentialBackoff struct {
+	Base time.Duration // Delay ceiling of the first retry (default: 100ms)
+	Max  time.Duration // Upper bound of any delay (default: 10s)
+}
+
+// Next returns a random delay for the given retry attempt.
+func (b ExponentialBackoff) Next(attempt int) time.Duration {
+	base, max := b.Base, b.Max
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 10 * time.Second
+	}
+
+	ceiling := max
+	if attempt < 32 && base<<uint(attempt) > 0 && base<<uint(attempt) < max {
+		ceiling = base << uint(attempt)
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// backoff returns the configured backoff strategy or the default.
+func (c *Client) backoff() Backoff {
+	if c.Backoff != nil {
+		return c.Backoff
+	}
+	return ExponentialBackoff{}
+}
+
+// do sends req for operation op, retrying up to MaxRetries times when
+// shouldRetry allows it. Between attempts it waits for the server's Retry-After
+// or the backoff delay and rewinds the request body. The last response or error
+// is returned and reported to the metrics collector.
+func (c *Client) do(op string, req *http.Request) (resp *http.Response, err error) {
+	start := time.Now()
+	defer func() { c.observe(op, start, req, resp, err) }()
+
+	// URLs from a client that cannot sign carry no signature
+	if err := c.checkSigning(); err != nil {
+		return nil, err
+	}
+	if err := c.applyExtraHeaders(req); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err = c.httpClient().Do(req)
+		if attempt >= c.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := c.backoff().Next(attempt)
+		if resp != nil {
+			if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); retryAfter > 0 {
+				wait = retryAfter
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// rewindable lets do retry a request whose body is an io.ReadSeeker other than
+// the in-memory readers net/http already knows how to replay, such as an
+// *os.File or io.SectionReader. Retries seek back to the body's current offset.
+// The transport never closes body, which stays owned by the caller. Other
+// bodies are sent once.
+func rewindable(req *http.Request, body io.Reader) {
+	seeker, ok := body.(io.ReadSeeker)
+	if !ok || req.GetBody != nil {
+		return
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return
+	}
+	req.Body = io.NopCloser(seeker)
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		return io.NopCloser(seeker), nil
+	}
+}
+
+// applyExtraHeaders adds the client's ExtraHeaders to req. It refuses to
+// replace a header the SDK has already set.
+func (c *Client) applyExtraHeaders(req *http.Request) error {
+	for name, value := range c.ExtraHeaders {
+		if req.Header.Get(name) != "" {
+			return fmt.Errorf("%w: extra header %s conflicts with a header set by the SDK", ErrInvalidConfig, http.CanonicalHeaderKey(name))
+		}
+		req.Header.Set(name, value)
+	}
+	return nil
+}
+
+// shouldRetry reports whether a request may be sent again. Requests whose body
+// cannot be rewound are never retried. 429 and 503 mean the server did not
+// process the request, so they are retried for any method; network errors and
+// other 5xx responses are retried only for idempotent methods.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	idempotent := false
+	switch req.Method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		idempotent = true
+	}
+
+	if err != nil {
+		return idempotent
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var noBackoff = BackoffFunc(func(int) time.Duration { return 0 })
+
+func TestRetry_RewindsBody(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("attempt %d: missing file part: %v", attempts, err)
+		} else {
+			file.Close()
+		}
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(FileResponse{Name: "test.txt"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.MaxRetries = 3
+	client.Backoff = noBackoff
+
+	if _, err := client.UploadBytes("test.txt", []byte("test"), nil); err != nil {
+		t.Fatalf("upload should succeed after retries: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetry_RewindsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.txt")
+	if err := os.WriteFile(path, []byte("file content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data []byte
+		if file, _, err := r.FormFile("file"); err == nil {
+			data, _ = io.ReadAll(file)
+			file.Close()
+		} else {
+			data, _ = io.ReadAll(r.Body)
+		}
+		bodies = append(bodies, string(data))
+		if len(bodies)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		json.NewEncoder(w).Encode(FileResponse{Name: "test.txt"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.MaxRetries = 1
+	client.Backoff = noBackoff
+
+	if _, err := client.Upload(path, nil); err != nil {
+		t.Fatalf("upload should succeed after retry: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	upload := &MultipartUpload{UploadID: "u1", client: client, parts: make(map[int]CompletedPart)}
+	if _, err := upload.UploadPart(1, file); err != nil {
+		t.Fatalf("UploadPart should succeed after retry: %v", err)
+	}
+
+	for i, body := range bodies {
+		if body != "file content" {
+			t.Errorf("attempt %d sent %q, want full file", i+1, body)
+		}
+	}
+	if len(bodies) != 4 {
+		t.Errorf("expected 4 attempts, got %d", len(bodies))
+	}
+}
+
+func TestRetry_Policy(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		expected int
+	}{
+		{"GET on 500", "GET", http.StatusInternalServerError, 3},
+		{"DELETE on 502", "DELETE", http.StatusBadGateway, 3},
+		{"POST on 429", "POST", http.StatusTooManyRequests, 3},
+		{"POST on 500", "POST", http.StatusInternalServerError, 1},
+		{"GET on 404", "GET", http.StatusNotFound, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+			client.MaxRetries = 2
+			client.Backoff = noBackoff
+
+			err := client.doJSON(tt.method, client.objectsPath(), nil, nil, nil, "test")
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("expected APIError with status %d, got %v", tt.status, err)
+			}
+			if attempts != tt.expected {
+				t.Errorf("expected %d attempts, got %d", tt.expected, attempts)
+			}
+		})
+	}
+}
+
+func TestRetry_DisabledByDefault(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if _, err := client.DownloadBytes("photo.jpg", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestRetry_UsesBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var calls []int
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.MaxRetries = 3
+	client.Backoff = BackoffFunc(func(attempt int) time.Duration {
+		calls = append(calls, attempt)
+		return 0
+	})
+
+	client.DownloadBytes("photo.jpg", nil)
+	if len(calls) != 3 || calls[0] != 0 || calls[2] != 2 {
+		t.Errorf("unexpected backoff attempts %v", calls)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff{Base: 10 * time.Millisecond, Max: 50 * time.Millisecond}
+
+	for attempt := 0; attempt < 64; attempt++ {
+		ceiling := b.Max
+		if attempt < 3 {
+			ceiling = b.Base << uint(attempt)
+		}
+		for i := 0; i < 20; i++ {
+			if d := b.Next(attempt); d < 0 || d > ceiling {
+				t.Fatalf("attempt %d: delay %v outside [0, %v]", attempt, d, ceiling)
+			}
+		}
+	}
+}
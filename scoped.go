@@ -0,0 +1,91 @@
+package sdk
+
+import "time"
+
+// ScopedClient is a client narrowed to one project and bucket, created with
+// Client.Scoped. It shares the parent's credentials, options and HTTP client.
+type ScopedClient struct {
+	client *Client
+}
+
+// Scoped returns a client for projectID and bucketName that shares c's
+// credentials, options and HTTP client (including its connection pool). The
+// options are copied when Scoped is called; later changes to c do not affect
+// the scoped client.
+//
+// Example:
+//
+//	avatars := client.Scoped(projectID, "avatars")
+//	resp, err := avatars.Upload("photo.jpg", nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	url := avatars.GetObjectURL(resp.ObjectKey(), time.Hour)
+func (c *Client) Scoped(projectID, bucketName string) *ScopedClient {
+	return &ScopedClient{client: &Client{
+		BaseURL:               c.BaseURL,
+		ProjectID:             projectID,
+		BucketName:            bucketName,
+		AccessKey:             c.AccessKey,
+		SecretKey:             c.SecretKey,
+		PreviousSecretKey:     c.PreviousSecretKey,
+		HTTPClient:            c.httpClient(),
+		TLSConfig:             c.TLSConfig,
+		InsecureSkipVerify:    c.InsecureSkipVerify,
+		DialTimeout:           c.DialTimeout,
+		TLSHandshakeTimeout:   c.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: c.ResponseHeaderTimeout,
+		DisableRedirects:      c.DisableRedirects,
+		SignatureVersion:      c.SignatureVersion,
+		ExpiresFormat:         c.ExpiresFormat,
+		ProxyURL:              c.ProxyURL,
+		PublicURLTemplate:     c.PublicURLTemplate,
+		DisablePublicURLs:     c.DisablePublicURLs,
+		APIVersion:            c.APIVersion,
+		MaxRetries:            c.MaxRetries,
+		Backoff:               c.Backoff,
+		Metrics:               c.Metrics,
+		BufferPool:            c.BufferPool,
+		MaxURLExpiry:          c.MaxURLExpiry,
+		URLCache:              c.URLCache,
+		ExtraHeaders:          c.ExtraHeaders,
+	}}
+}
+
+// ProjectID returns the project the client is scoped to.
+func (s *ScopedClient) ProjectID() string {
+	return s.client.ProjectID
+}
+
+// BucketName returns the bucket the client is scoped to.
+func (s *ScopedClient) BucketName() string {
+	return s.client.BucketName
+}
+
+// GetObjectURL returns a presigned GET URL for filename in the scoped bucket.
+// See Client.GetObjectURL.
+func (s *ScopedClient) GetObjectURL(filename string, expiresIn time.Duration) string {
+	return s.client.GetObjectURL(filename, expiresIn)
+}
+
+// Upload uploads a local file to the scoped bucket. See Client.Upload.
+func (s *ScopedClient) Upload(filePath string, opts *UploadOptions) (*FileResponse, error) {
+	return s.client.Upload(filePath, opts)
+}
+
+// UploadBytes uploads content from memory to the scoped bucket. See
+// Client.UploadBytes.
+func (s *ScopedClient) UploadBytes(filename string, data []byte, opts *UploadOptions) (*FileResponse, error) {
+	return s.client.UploadBytes(filename, data, opts)
+}
+
+// Download downloads an object from the scoped bucket to localPath. See
+// Client.Download.
+func (s *ScopedClient) Download(filename, localPath string, expiresIn time.Duration) error {
+	return s.client.Download(filename, localPath, expiresIn)
+}
+
+// Delete deletes an object from the scoped bucket. See Client.Delete.
+func (s *ScopedClient) Delete(filename string, expiresIn time.Duration) error {
+	return s.client.Delete(filename, expiresIn)
+}
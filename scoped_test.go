@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScoped(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.HTTPClient = server.Client()
+	scoped := client.Scoped("other-project", "avatars")
+
+	if scoped.client.httpClient() != client.httpClient() {
+		t.Error("scoped client should share the parent's HTTP client")
+	}
+
+	if scoped.ProjectID() != "other-project" || scoped.BucketName() != "avatars" {
+		t.Errorf("unexpected scope %s/%s", scoped.ProjectID(), scoped.BucketName())
+	}
+	if client.BucketName != testBucketName {
+		t.Error("Scoped should not change the parent client")
+	}
+
+	if _, err := scoped.UploadBytes("hello.txt", []byte("hello"), nil); err != nil {
+		t.Fatalf("UploadBytes failed: %v", err)
+	}
+	if want := "/api/v1/projects/other-project/buckets/avatars/objects"; len(paths) != 1 || paths[0] != want {
+		t.Errorf("expected upload to %s, got %v", want, paths)
+	}
+
+	url := scoped.GetObjectURL("abc.txt", time.Hour)
+	if !strings.Contains(url, "/projects/other-project/buckets/avatars/objects/abc.txt?") {
+		t.Errorf("unexpected scoped URL %s", url)
+	}
+	if err := client.VerifyPresignedURL("GET", url); err != nil {
+		t.Errorf("scoped URL should be signed with the parent's credentials: %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignatureVersion_Default(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	presignedURL := client.GetObjectURL("photo.jpg", time.Hour)
+	parsed, _ := url.Parse(presignedURL)
+	if got := parsed.Query().Get("X-Mos-SignatureVersion"); got != "v1" {
+		t.Errorf("expected X-Mos-SignatureVersion=v1, got %q", got)
+	}
+
+	// Explicit v1 must produce the same signature as the default
+	explicit := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	explicit.SignatureVersion = SignatureV1
+	if client.GenerateSignature("GET", "/path", 1735344000) != explicit.GenerateSignature("GET", "/path", 1735344000) {
+		t.Error("default signature version should be v1")
+	}
+}
+
+func TestSignatureVersion_Unsupported(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.SignatureVersion = "v99"
+
+	if err := client.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	if sig := client.GenerateSignature("GET", "/path", 1735344000); sig != "" {
+		t.Errorf("unsupported version should not produce a signature, got %q", sig)
+	}
+
+	// Nothing is signed with an empty signature
+	req, _ := http.NewRequest("GET", testBaseURL+"/api/v1/path", nil)
+	if err := client.SignRequest(req, time.Hour); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("SignRequest: expected ErrInvalidConfig, got %v", err)
+	}
+	if req.URL.RawQuery != "" {
+		t.Errorf("failed SignRequest should leave the request unchanged, got %q", req.URL.RawQuery)
+	}
+	client.URLCache = NewURLCache(10, time.Minute)
+	if u := client.GetObjectURL("photo.jpg", time.Hour); u != "" || client.URLCache.Len() != 0 {
+		t.Errorf("GetObjectURL should return no URL and cache nothing, got %q", u)
+	}
+	if _, err := client.GetObjectURLSeconds("photo.jpg", 60); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("GetObjectURLSeconds: expected ErrInvalidConfig, got %v", err)
+	}
+	if _, err := client.UploadBytes("hello.txt", []byte("hello"), nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("UploadBytes: expected ErrInvalidConfig, got %v", err)
+	}
+	if _, err := client.DownloadBytes("hello.txt", nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("DownloadBytes: expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestSignatureVersion_NotSigned(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	params := url.Values{"X-Mos-SignatureVersion": {"v1"}}
+	if client.GenerateSignatureWithParams("GET", "/path", 1735344000, params) != client.GenerateSignature("GET", "/path", 1735344000) {
+		t.Error("X-Mos-SignatureVersion should not be part of the v1 string-to-sign")
+	}
+}
+
+func TestSignatureV2_BindsAccessKey(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.SignatureVersion = SignatureV2
+
+	if err := client.Validate(); err != nil {
+		t.Errorf("v2 should be a valid signature version: %v", err)
+	}
+
+	// v2 signs the access key on the fourth line
+	mac := hmac.New(sha256.New, []byte(testSecretKey))
+	mac.Write([]byte("GET\n/path\n1735344000\n" + testAccessKey + "\nlimit=1"))
+	expected := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+	if got := client.GenerateSignatureWithParams("GET", "/path", 1735344000, url.Values{"limit": {"1"}}); got != expected {
+		t.Errorf("signature mismatch:\nexpected: %s\ngot:      %s", expected, got)
+	}
+
+	// The same secret with another access key yields a different signature
+	other := NewClient(testBaseURL, testProjectID, testBucketName, "MOS_OTHER_KEY", testSecretKey)
+	other.SignatureVersion = SignatureV2
+	if client.GenerateSignature("GET", "/path", 1735344000) == other.GenerateSignature("GET", "/path", 1735344000) {
+		t.Error("v2 signatures should depend on the access key")
+	}
+
+	parsed, _ := url.Parse(client.GetObjectURL("photo.jpg", time.Hour))
+	if got := parsed.Query().Get("X-Mos-SignatureVersion"); got != "v2" {
+		t.Errorf("expected X-Mos-SignatureVersion=v2, got %q", got)
+	}
+}
+
+func TestExpiresFormat_RFC3339(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.ExpiresFormat = ExpiresRFC3339
+
+	if err := client.Validate(); err != nil {
+		t.Errorf("rfc3339 should be a valid expires format: %v", err)
+	}
+
+	// The encoded timestamp is what gets signed
+	mac := hmac.New(sha256.New, []byte(testSecretKey))
+	mac.Write([]byte("GET\n/path\n2024-12-28T00:00:00Z"))
+	expected := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+	if got := client.GenerateSignature("GET", "/path", 1735344000); got != expected {
+		t.Errorf("signature mismatch:\nexpected: %s\ngot:      %s", expected, got)
+	}
+
+	presignedURL := client.GetObjectURL("photo.jpg", time.Hour)
+	parsed, _ := url.Parse(presignedURL)
+	if _, err := time.Parse(time.RFC3339, parsed.Query().Get("X-Mos-Expires")); err != nil {
+		t.Errorf("X-Mos-Expires should be RFC 3339: %v", err)
+	}
+	if err := client.VerifyPresignedURL("GET", presignedURL); err != nil {
+		t.Errorf("URL should verify with the same format: %v", err)
+	}
+
+	client.ExpiresFormat = ExpiresUnix
+	if err := client.VerifyPresignedURL("GET", presignedURL); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("URL should not verify with another format, got %v", err)
+	}
+
+	client.ExpiresFormat = "iso"
+	if err := client.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig for unknown format, got %v", err)
+	}
+}
+
+func TestDebugSignature(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	debug := client.DebugSignature("GET", "/path?response-content-type=text%2Fplain", 1735344000)
+	if debug.Err != nil {
+		t.Fatalf("unexpected error: %v", debug.Err)
+	}
+	if want := "GET\n/path\n1735344000\nresponse-content-type=text%2Fplain"; debug.StringToSign != want {
+		t.Errorf("expected string-to-sign %q, got %q", want, debug.StringToSign)
+	}
+	params := url.Values{"response-content-type": {"text/plain"}}
+	if want := client.GenerateSignatureWithParams("GET", "/path", 1735344000, params); debug.Signature != want {
+		t.Errorf("expected signature %s, got %s", want, debug.Signature)
+	}
+	sum, _ := base64.URLEncoding.DecodeString(debug.Signature)
+	if hex.EncodeToString(sum) != debug.HMACHex {
+		t.Errorf("HMAC hex %s does not match signature", debug.HMACHex)
+	}
+	if strings.Contains(debug.String(), testSecretKey) {
+		t.Error("debug output must not contain the secret key")
+	}
+
+	// A URL signed with RFC 3339 expiries can be debugged via ParseExpires
+	client.ExpiresFormat = ExpiresRFC3339
+	u, _ := url.Parse(client.GetObjectURL("photo.jpg", time.Hour))
+	expires, err := client.ParseExpires(u.Query().Get("X-Mos-Expires"))
+	if err != nil {
+		t.Fatalf("ParseExpires failed: %v", err)
+	}
+	if debug := client.DebugSignature("GET", u.EscapedPath(), expires); debug.Signature != u.Query().Get("X-Mos-Signature") {
+		t.Errorf("debug signature %s should match the URL's %s", debug.Signature, u.Query().Get("X-Mos-Signature"))
+	}
+
+	client.SignatureVersion = "v9"
+	if debug := client.DebugSignature("GET", "/path", 1735344000); debug.Err == nil || debug.Signature != "" {
+		t.Errorf("expected error for unsupported version, got %+v", debug)
+	}
+}
@@ -0,0 +1,256 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// UploadReader uploads content of unknown length, such as a pipe or a live
+// stream, without holding it in memory. The multipart form is streamed with
+// chunked transfer encoding, so the request cannot be retried. Servers that
+// require a Content-Length answer with 411, which matches ErrLengthRequired;
+// set opts.SpoolToDisk to buffer the form in a temporary file first instead.
+// The returned FileResponse.Stats.Chunked reports which path was taken.
+//
+// MaxSize and AllowedContentTypes are not checked, since the content is not
+// known before it is sent.
+//
+// Example:
+//
+//	cmd := exec.Command("pg_dump", "mydb")
+//	out, _ := cmd.StdoutPipe()
+//	cmd.Start()
+//	resp, err := client.UploadReader("backup.sql", out, nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Uploaded %d bytes (chunked: %v)\n", resp.Stats.Bytes, resp.Stats.Chunked)
+func (c *Client) UploadReader(filename string, r io.Reader, opts *UploadOptions) (*FileResponse, error) {
+	return c.uploadReader(context.Background(), filename, r, opts)
+}
+
+// UploadHandle is an upload running in the background, started with
+// StartUploadReader.
+type UploadHandle struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	resp   *FileResponse
+	err    error
+}
+
+// StartUploadReader starts UploadReader in a new goroutine and returns a
+// handle to wait for or abort it. Cancel may be called from any goroutine;
+// the upload then ends with an error matching context.Canceled.
+//
+// Example:
+//
+//	upload := client.StartUploadReader("stream.ts", liveStream, nil)
+//	go func() {
+//	    <-stop
+//	    upload.Cancel()
+//	}()
+//	resp, err := upload.Wait()
+//	if errors.Is(err, context.Canceled) {
+//	    log.Println("upload aborted")
+//	}
+func (c *Client) StartUploadReader(filename string, r io.Reader, opts *UploadOptions) *UploadHandle {
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &UploadHandle{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(h.done)
+		defer cancel()
+		h.resp, h.err = c.uploadReader(ctx, filename, r, opts)
+	}()
+	return h
+}
+
+// Cancel aborts the upload: the request is cancelled and the form stream is
+// closed. It has no effect once the upload has finished.
+func (h *UploadHandle) Cancel() {
+	h.cancel()
+}
+
+// Done returns a channel that is closed when the upload has finished.
+func (h *UploadHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait blocks until the upload has finished and returns its result.
+func (h *UploadHandle) Wait() (*FileResponse, error) {
+	<-h.done
+	return h.resp, h.err
+}
+
+// uploadReader implements UploadReader, aborting when ctx is cancelled.
+func (c *Client) uploadReader(ctx context.Context, filename string, r io.Reader, opts *UploadOptions) (*FileResponse, error) {
+	// Set defaults
+	if opts == nil {
+		opts = &UploadOptions{ExpiresIn: time.Hour}
+	}
+	if opts.ExpiresIn == 0 {
+		opts.ExpiresIn = time.Hour
+	}
+	filename = opts.withExtension(filename)
+
+	counter := NewProgressReader(&contextReader{ctx: ctx, r: r}, nil)
+	if opts.SpoolToDisk {
+		return c.uploadSpooled(ctx, filename, counter, opts)
+	}
+	return c.streamUpload(ctx, filename, counter, -1, opts)
+}
+
+// streamUpload sends the form for counter through a pipe. A non-negative size
+// is the exact length of the content and, for uncompressed uploads, lets the
+// form be sent with a Content-Length; otherwise it is sent chunked.
+func (c *Client) streamUpload(ctx context.Context, filename string, counter *ProgressReader, size int64, opts *UploadOptions) (*FileResponse, error) {
+	// Stream the form through a pipe; closing the read side stops the writer
+	// if the request fails before the whole body was sent, and cancelling
+	// ctx closes the write side
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	stop := context.AfterFunc(ctx, func() { pw.CloseWithError(ctx.Err()) })
+	defer stop()
+	writer := multipart.NewWriter(pw)
+
+	length := int64(-1)
+	if size >= 0 && !opts.Compress {
+		overhead, err := formOverhead(writer.Boundary(), filename, opts)
+		if err != nil {
+			return nil, err
+		}
+		length = overhead + size
+	}
+
+	go func() {
+		pw.CloseWithError(writeMultipartBody(writer, filename, counter, opts))
+	}()
+
+	return c.postUpload(ctx, pr, length, writer.FormDataContentType(), counter, opts)
+}
+
+// formOverhead returns the length of the upload form without its file
+// content, i.e. what writeMultipartBody adds around uncompressed content.
+func formOverhead(boundary, filename string, opts *UploadOptions) (int64, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return 0, err
+	}
+	if err := writeMultipartBody(writer, filename, strings.NewReader(""), opts); err != nil {
+		return 0, err
+	}
+	return int64(buf.Len()), nil
+}
+
+// UploadFS uploads the file name from fsys, e.g. an embed.FS or an
+// fstest.MapFS in tests. The content is streamed; when the file reports its
+// size the form is sent with a Content-Length, otherwise it is sent chunked
+// like UploadReader. Since the file is read only once, the request cannot be
+// retried.
+//
+// MaxSize is checked against the reported size and AllowedContentTypes
+// against the first bytes of the file. SkipIfExists requires a file that
+// implements io.Seeker, as the files of embed.FS, os.DirFS and fstest.MapFS do.
+//
+// Example:
+//
+//	//go:embed assets
+//	var assets embed.FS
+//
+//	resp, err := client.UploadFS(assets, "assets/logo.png", nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("File uploaded: %s\n", resp.URL)
+func (c *Client) UploadFS(fsys fs.FS, name string, opts *UploadOptions) (*FileResponse, error) {
+	// Set defaults
+	if opts == nil {
+		opts = &UploadOptions{ExpiresIn: time.Hour}
+	}
+	if opts.ExpiresIn == 0 {
+		opts.ExpiresIn = time.Hour
+	}
+	filename := opts.withExtension(path.Base(name))
+
+	// Open file
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	// Use the size when the file system reports one
+	size := int64(-1)
+	if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+		size = info.Size()
+		if err := opts.checkSize(size); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sniff the content type without consuming the head of the stream
+	var r io.Reader = file
+	if len(opts.AllowedContentTypes) > 0 {
+		br := bufio.NewReaderSize(file, 512)
+		head, err := br.Peek(512)
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if err := opts.checkContentType(filename, head); err != nil {
+			return nil, err
+		}
+		r = br
+	}
+
+	// Hashing rewinds the file to the start, so the upload then reads it
+	// directly rather than through the buffered head
+	if opts.SkipIfExists {
+		rs, ok := file.(io.ReadSeeker)
+		if !ok {
+			return nil, fmt.Errorf("%w: SkipIfExists needs a seekable file, %s is not", ErrInvalidConfig, name)
+		}
+		existing, uploadOpts, err := c.findExisting(filename, rs, opts)
+		if err != nil || existing != nil {
+			return existing, err
+		}
+		opts = uploadOpts
+		r = file
+	}
+
+	counter := NewProgressReader(r, nil)
+	return c.streamUpload(context.Background(), filename, counter, size, opts)
+}
+
+// uploadSpooled writes the upload form to a temporary file and sends it with a
+// Content-Length.
+func (c *Client) uploadSpooled(ctx context.Context, filename string, counter *ProgressReader, opts *UploadOptions) (*FileResponse, error) {
+	spool, err := os.CreateTemp("", "mos-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spool file: %w", err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	writer := multipart.NewWriter(spool)
+	if err := writeMultipartBody(writer, filename, counter, opts); err != nil {
+		return nil, err
+	}
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to size spool file: %w", err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind spool file: %w", err)
+	}
+
+	return c.postUpload(ctx, spool, size, writer.FormDataContentType(), counter, opts)
+}
@@ -0,0 +1,233 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestUploadReader_Chunked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 || len(r.TransferEncoding) == 0 || r.TransferEncoding[0] != "chunked" {
+			t.Errorf("expected chunked body, got length %d and encoding %v", r.ContentLength, r.TransferEncoding)
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("failed to read file part: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != "streamed content" {
+			t.Errorf("unexpected content %q", data)
+		}
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	// io.MultiReader hides the length of the strings.Reader
+	resp, err := client.UploadReader("test.txt", io.MultiReader(strings.NewReader("streamed content")), nil)
+	if err != nil {
+		t.Fatalf("UploadReader failed: %v", err)
+	}
+	if !resp.Stats.Chunked {
+		t.Error("expected Stats.Chunked to be set")
+	}
+	if resp.Stats.Bytes != int64(len("streamed content")) {
+		t.Errorf("expected %d bytes, got %d", len("streamed content"), resp.Stats.Bytes)
+	}
+}
+
+func TestUploadReader_LengthRequired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength < 0 {
+			w.WriteHeader(http.StatusLengthRequired)
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("failed to read file part: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != "spooled content" {
+			t.Errorf("unexpected content %q", data)
+		}
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	_, err := client.UploadReader("test.txt", io.MultiReader(strings.NewReader("spooled content")), nil)
+	if !errors.Is(err, ErrLengthRequired) {
+		t.Fatalf("expected ErrLengthRequired, got %v", err)
+	}
+
+	resp, err := client.UploadReader("test.txt", io.MultiReader(strings.NewReader("spooled content")), &UploadOptions{SpoolToDisk: true})
+	if err != nil {
+		t.Fatalf("UploadReader with SpoolToDisk failed: %v", err)
+	}
+	if resp.Stats.Chunked {
+		t.Error("expected Stats.Chunked to be false for a spooled upload")
+	}
+}
+
+func TestStartUploadReader_Cancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	// A stream that produces some data and then never ends
+	source, sink := io.Pipe()
+	defer sink.Close()
+	go sink.Write([]byte("first chunk"))
+
+	upload := client.StartUploadReader("stream.ts", source, nil)
+	select {
+	case <-upload.Done():
+		t.Fatal("upload should still be running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	upload.Cancel()
+	select {
+	case <-upload.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("upload did not stop after Cancel")
+	}
+	if _, err := upload.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestStartUploadReader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	upload := client.StartUploadReader("test.txt", strings.NewReader("content"), nil)
+	resp, err := upload.Wait()
+	if err != nil || resp.Name != "abc.txt" {
+		t.Fatalf("unexpected result %+v (%v)", resp, err)
+	}
+	upload.Cancel() // no effect after completion
+}
+
+func TestUploadFS(t *testing.T) {
+	const content = "embedded asset"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength <= 0 || len(r.TransferEncoding) != 0 {
+			t.Errorf("expected a Content-Length, got length %d and encoding %v", r.ContentLength, r.TransferEncoding)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("failed to read file part: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != content || header.Filename != "logo.txt" {
+			t.Errorf("unexpected file %q with content %q", header.Filename, data)
+		}
+		if r.FormValue("metadata") != `{"source":"embed"}` {
+			t.Errorf("unexpected metadata %q", r.FormValue("metadata"))
+		}
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	fsys := fstest.MapFS{"assets/logo.txt": {Data: []byte(content)}}
+
+	resp, err := client.UploadFS(fsys, "assets/logo.txt", &UploadOptions{
+		Metadata: map[string]interface{}{"source": "embed"},
+	})
+	if err != nil {
+		t.Fatalf("UploadFS failed: %v", err)
+	}
+	if resp.Stats.Chunked || resp.Stats.Bytes != int64(len(content)) {
+		t.Errorf("expected %d bytes sent unchunked, got %+v", len(content), resp.Stats)
+	}
+
+	// Limits are checked before anything is sent
+	if _, err := client.UploadFS(fsys, "assets/logo.txt", &UploadOptions{MaxSize: 4}); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+	_, err = client.UploadFS(fsys, "assets/logo.txt", &UploadOptions{AllowedContentTypes: []string{"image/png"}})
+	if !errors.Is(err, ErrDisallowedType) {
+		t.Errorf("expected ErrDisallowedType, got %v", err)
+	}
+	if _, err := client.UploadFS(fsys, "missing.txt", nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestUploadFS_EarlyRejection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadFull(r.Body, make([]byte, 10))
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	fsys := fstest.MapFS{"big.bin": {Data: make([]byte, 4<<20)}}
+
+	_, err := client.UploadFS(fsys, "big.bin", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected a 413 APIError, got %v", err)
+	}
+	if strings.Contains(err.Error(), "empty upload") {
+		t.Errorf("a partly sent stream should not be reported as empty: %v", err)
+	}
+}
+
+func TestUploadFS_SkipIfExistsAfterTypeCheck(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 600))
+	script := []byte("#!/bin/sh\necho hi\n")
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "HEAD" {
+			// Claim the script is already stored, so only the type check can stop it
+			if strings.HasSuffix(r.URL.Path, ".sh") {
+				w.Header().Set("Content-Length", fmt.Sprint(len(script)))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		file, _, _ := r.FormFile("file")
+		received, _ = io.ReadAll(file)
+		fmt.Fprint(w, `{"name":"abc.png"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	fsys := fstest.MapFS{"run.sh": {Data: script}, "logo.png": {Data: png}}
+	opts := &UploadOptions{SkipIfExists: true, AllowedContentTypes: []string{"image/*"}}
+
+	if _, err := client.UploadFS(fsys, "run.sh", opts); !errors.Is(err, ErrDisallowedType) {
+		t.Errorf("expected ErrDisallowedType before the existence check, got %v", err)
+	}
+
+	// The sniffed head is not sent twice after hashing rewinds the file
+	if _, err := client.UploadFS(fsys, "logo.png", opts); err != nil {
+		t.Fatalf("UploadFS failed: %v", err)
+	}
+	if string(received) != string(png) {
+		t.Errorf("expected %d bytes uploaded intact, got %d", len(png), len(received))
+	}
+}
@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// objectTags is the request and response body of the tagging endpoints.
+type objectTags struct {
+	Tags map[string]string `json:"tags"`
+}
+
+// tagsPath returns the API path of an object's tag set.
+func (c *Client) tagsPath(filename string) string {
+	return fmt.Sprintf("%s/projects/%s/buckets/%s/tags/%s",
+		c.apiRoot(),
+		url.PathEscape(c.ProjectID),
+		url.PathEscape(c.BucketName),
+		escapeKey(filename),
+	)
+}
+
+// ObjectTagsURL generates a presigned URL for the tagging endpoint of an object.
+// Use "GET" to read tags and "PUT" to replace them.
+//
+// Example:
+//
+//	url := client.ObjectTagsURL("PUT", "photo.jpg", time.Hour)
+func (c *Client) ObjectTagsURL(method, filename string, expiresIn time.Duration) string {
+	return c.GeneratePresignedURL(method, c.tagsPath(filename), expiresIn)
+}
+
+// SetObjectTags replaces the tags of an object. Unlike upload metadata, tags can
+// be changed at any time after upload, e.g. to mark objects for expiry sweeps.
+// Passing an empty map removes all tags.
+//
+// Example:
+//
+//	err := client.SetObjectTags("8aabd7f7-1dbf-4ea4-8918-db66069746e7.jpg", map[string]string{
+//	    "lifecycle": "expire-30d",
+//	})
+func (c *Client) SetObjectTags(filename string, tags map[string]string) error {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	return c.doJSON("PUT", c.tagsPath(filename), nil, objectTags{Tags: tags}, nil, "set tags")
+}
+
+// GetObjectTags returns the tags of an object. An object without tags yields an
+// empty, non-nil map.
+//
+// Example:
+//
+//	tags, err := client.GetObjectTags("8aabd7f7-1dbf-4ea4-8918-db66069746e7.jpg")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(tags["lifecycle"])
+func (c *Client) GetObjectTags(filename string) (map[string]string, error) {
+	var result objectTags
+	if err := c.doJSON("GET", c.tagsPath(filename), nil, nil, &result, "get tags"); err != nil {
+		return nil, err
+	}
+	if result.Tags == nil {
+		result.Tags = map[string]string{}
+	}
+	return result.Tags, nil
+}
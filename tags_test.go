@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObjectTags(t *testing.T) {
+	stored := map[string]string{}
+	expectedPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/tags/photo.jpg", testProjectID, testBucketName)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+		if r.URL.Query().Get("X-Mos-Signature") == "" {
+			t.Error("tagging requests should be presigned")
+		}
+		switch r.Method {
+		case "PUT":
+			var body objectTags
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			stored = body.Tags
+			w.WriteHeader(http.StatusNoContent)
+		case "GET":
+			json.NewEncoder(w).Encode(objectTags{Tags: stored})
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	if err := client.SetObjectTags("photo.jpg", map[string]string{"lifecycle": "expire-30d"}); err != nil {
+		t.Fatalf("set tags should succeed: %v", err)
+	}
+
+	tags, err := client.GetObjectTags("photo.jpg")
+	if err != nil {
+		t.Fatalf("get tags should succeed: %v", err)
+	}
+	if tags["lifecycle"] != "expire-30d" {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+}
+
+func TestObjectTagsURL(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	tagsURL := client.ObjectTagsURL("GET", "photo.jpg", time.Hour)
+	expectedPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/tags/photo.jpg", testProjectID, testBucketName)
+	if !strings.Contains(tagsURL, expectedPath) {
+		t.Errorf("URL should contain correct path: %s", tagsURL)
+	}
+}
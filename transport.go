@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// httpClient returns the HTTP client used for all requests. It is built on first
+// use: an explicit HTTPClient wins, a dedicated transport is created when any
+// transport option is set, and http.DefaultClient is shared otherwise. A
+// dedicated http.Client (sharing the default transport) is also created when
+// DisableRedirects is set. The sync.Once makes concurrent first calls build a
+// single client and transport.
+func (c *Client) httpClient() *http.Client {
+	c.httpOnce.Do(func() {
+		switch {
+		case c.HTTPClient != nil:
+			c.client = c.HTTPClient
+		case c.needsTransport():
+			c.client = &http.Client{Transport: c.newTransport(), CheckRedirect: c.checkRedirect}
+		case c.DisableRedirects:
+			c.client = &http.Client{CheckRedirect: c.checkRedirect}
+		default:
+			c.client = http.DefaultClient
+		}
+	})
+	return c.client
+}
+
+// checkRedirect implements http.Client.CheckRedirect for DisableRedirects:
+// when unset redirects follow the default policy, otherwise the redirect response is returned
+// as is and the operation fails with an error matching ErrRedirect.
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if c.DisableRedirects {
+		return http.ErrUseLastResponse
+	}
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	return nil
+}
+
+// needsTransport reports whether any option requires a dedicated transport.
+func (c *Client) needsTransport() bool {
+	return c.TLSConfig != nil || c.InsecureSkipVerify || c.ProxyURL != "" ||
+		c.DialTimeout > 0 || c.TLSHandshakeTimeout > 0 || c.ResponseHeaderTimeout > 0
+}
+
+// newTransport builds a transport from the client's options, starting from the
+// defaults of http.DefaultTransport (including proxy settings from the environment).
+func (c *Client) newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := c.TLSConfig.Clone()
+	if c.InsecureSkipVerify {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig.InsecureSkipVerify = true // #nosec G402 - explicit opt-in for development
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	// Phase timeouts fail slow connections fast without capping how long
+	// a large body may take to transfer
+	if c.DialTimeout > 0 {
+		dialer := &net.Dialer{Timeout: c.DialTimeout, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+	}
+	if c.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = c.TLSHandshakeTimeout
+	}
+	if c.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = c.ResponseHeaderTimeout
+	}
+
+	if c.ProxyURL != "" {
+		proxyURL, err := parseProxyURL(c.ProxyURL)
+		if err != nil {
+			// Surface the misconfiguration on every request rather than
+			// silently bypassing the proxy.
+			transport.Proxy = func(*http.Request) (*url.URL, error) { return nil, err }
+		} else {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	return transport
+}
+
+// parseProxyURL parses and validates a proxy URL.
+func parseProxyURL(rawurl string) (*url.URL, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %v", rawurl, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", rawurl)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", rawurl)
+	}
+	return u, nil
+}
+
+// Close releases idle connections held by the transport the client created for
+// its TLS or proxy options. It is a no-op when the client uses http.DefaultClient
+// or a caller-supplied HTTPClient, whose lifetime belongs to the caller. The
+// client remains usable after Close; new connections are dialed on demand.
+//
+// Example:
+//
+//	client := sdk.NewClient(baseURL, projectID, bucketName, accessKey, secretKey)
+//	client.ProxyURL = "http://proxy.internal:3128"
+//	defer client.Close()
+func (c *Client) Close() error {
+	hc := c.httpClient()
+	if hc == http.DefaultClient || hc == c.HTTPClient || hc.Transport == nil {
+		return nil
+	}
+	hc.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,274 @@
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_Default(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	if client.httpClient() != http.DefaultClient {
+		t.Error("client without transport options should use http.DefaultClient")
+	}
+}
+
+func TestHTTPClient_Explicit(t *testing.T) {
+	custom := &http.Client{}
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.HTTPClient = custom
+
+	if client.httpClient() != custom {
+		t.Error("explicit HTTPClient should be used")
+	}
+}
+
+func TestTLSConfig_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Untrusted certificate should fail
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrUnreachable) {
+		t.Errorf("expected certificate verification failure, got %v", err)
+	}
+
+	// Trusting the server's CA should succeed
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	client = NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.TLSConfig = &tls.Config{RootCAs: pool}
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("custom CA should be trusted: %v", err)
+	}
+}
+
+func TestTLSConfig_InsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.InsecureSkipVerify = true
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("InsecureSkipVerify should accept self-signed certificates: %v", err)
+	}
+}
+
+// TestHTTPClient_ConcurrentInit fires concurrent first requests on a fresh
+// client that owns a transport. Run with -race to check the lazy init.
+func TestHTTPClient_ConcurrentInit(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"abc.txt"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.InsecureSkipVerify = true
+	defer client.Close()
+
+	const workers = 32
+	clients := make(chan *http.Client, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.UploadBytes("hello.txt", []byte("hello"), nil); err != nil {
+				t.Errorf("concurrent upload failed: %v", err)
+			}
+			clients <- client.httpClient()
+		}()
+	}
+	wg.Wait()
+	close(clients)
+
+	for hc := range clients {
+		if hc != client.httpClient() {
+			t.Fatal("concurrent first calls should share a single HTTP client")
+		}
+	}
+}
+
+func TestTransportTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.DialTimeout = 5 * time.Second
+	client.TLSHandshakeTimeout = 3 * time.Second
+	client.ResponseHeaderTimeout = 50 * time.Millisecond
+	defer client.Close()
+
+	transport, ok := client.httpClient().Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("timeouts should give the client its own transport")
+	}
+	if transport.TLSHandshakeTimeout != 3*time.Second {
+		t.Errorf("TLSHandshakeTimeout = %v, want 3s", transport.TLSHandshakeTimeout)
+	}
+	if transport.DialContext == nil {
+		t.Error("DialTimeout should install a dialer")
+	}
+
+	start := time.Now()
+	if err := client.Ping(context.Background()); err == nil {
+		t.Fatal("expected a timeout waiting for response headers")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("ResponseHeaderTimeout not applied, request took %v", elapsed)
+	}
+}
+
+func TestProxyURL(t *testing.T) {
+	proxied := false
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL
+		proxied = r.URL.Host == "storage.internal"
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	client := NewClient("http://storage.internal", testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.ProxyURL = proxy.URL
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("ping through proxy should succeed: %v", err)
+	}
+	if !proxied {
+		t.Error("request should have been routed through the proxy")
+	}
+}
+
+func TestProxyURL_Invalid(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.ProxyURL = "ftp://proxy.example.com"
+
+	if err := client.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	if err := client.Ping(context.Background()); err == nil {
+		t.Error("requests should fail with an invalid proxy URL")
+	}
+}
+
+func TestClose_OwnedTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.InsecureSkipVerify = true
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+
+	// The client keeps working after Close
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("Ping after Close failed: %v", err)
+	}
+}
+
+func TestClose_SharedClient(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	if err := client.Close(); err != nil {
+		t.Errorf("Close on default client should be a no-op, got %v", err)
+	}
+}
+
+func TestExtraHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant-ID") != "acme" {
+			t.Errorf("%s %s: missing tenant header", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.ExtraHeaders = map[string]string{"X-Tenant-ID": "acme"}
+
+	if _, err := client.UploadBytes("a.txt", []byte("a"), nil); err != nil {
+		t.Errorf("upload failed: %v", err)
+	}
+	if _, err := client.DownloadBytes("a.txt", nil); err != nil {
+		t.Errorf("download failed: %v", err)
+	}
+	if err := client.Delete("a.txt", 0); err != nil {
+		t.Errorf("delete failed: %v", err)
+	}
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("ping failed: %v", err)
+	}
+}
+
+func TestExtraHeaders_Conflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("conflicting request should not be sent")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.ExtraHeaders = map[string]string{"content-type": "text/plain"}
+
+	if _, err := client.UploadBytes("a.txt", []byte("a"), nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestDisableRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/edge/photo.jpg" {
+			w.Write([]byte("edge content"))
+			return
+		}
+		http.Redirect(w, r, "/edge/photo.jpg", http.StatusFound)
+	}))
+	defer server.Close()
+
+	// Redirects are followed by default, also for clients built without NewClient
+	clients := []*Client{
+		NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey),
+		{BaseURL: server.URL, ProjectID: testProjectID, BucketName: testBucketName, AccessKey: testAccessKey, SecretKey: testSecretKey},
+	}
+	for _, client := range clients {
+		data, err := client.DownloadBytes("photo.jpg", nil)
+		if err != nil {
+			t.Fatalf("redirect should be followed by default: %v", err)
+		}
+		if string(data) != "edge content" {
+			t.Errorf("unexpected content %q", data)
+		}
+	}
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.DisableRedirects = true
+	_, err := client.DownloadBytes("photo.jpg", nil)
+	if !errors.Is(err, ErrRedirect) {
+		t.Fatalf("expected ErrRedirect, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Location != server.URL+"/edge/photo.jpg" {
+		t.Errorf("expected Location %s, got %+v", server.URL+"/edge/photo.jpg", apiErr)
+	}
+}
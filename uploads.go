@@ -0,0 +1,249 @@
+package sdk
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// MultipartUpload is an in-progress upload of a large object in parts. Parts
+// can be uploaded in any order and concurrently; Complete assembles them in
+// part-number order. Create one with CreateMultipartUpload.
+type MultipartUpload struct {
+	Key      string // Object key the upload will be stored under
+	UploadID string // Server-assigned upload ID
+
+	client *Client
+	mu     sync.Mutex
+	parts  map[int]CompletedPart
+}
+
+// Part is one piece of a multipart upload. Numbers start at 1 and determine
+// the position of the part in the assembled object.
+type Part struct {
+	Number int
+	Reader io.Reader
+}
+
+// CompletedPart identifies an uploaded part by its number and the ETag the
+// server returned for it.
+type CompletedPart struct {
+	Number int    `json:"part_number"`
+	ETag   string `json:"etag"`
+}
+
+// createUploadRequest is the request body of CreateMultipartUpload.
+type createUploadRequest struct {
+	Key string `json:"key"`
+}
+
+// createUploadResponse is the response body of CreateMultipartUpload.
+type createUploadResponse struct {
+	UploadID string `json:"upload_id"`
+	Key      string `json:"key"`
+}
+
+// completeUploadRequest is the request body of MultipartUpload.Complete.
+type completeUploadRequest struct {
+	Parts []CompletedPart `json:"parts"`
+}
+
+// uploadsPath returns the API path of the multipart upload collection, or of a
+// single upload and its sub-resources when elems are given.
+func (c *Client) uploadsPath(elems ...string) string {
+	p := fmt.Sprintf("%s/projects/%s/buckets/%s/uploads",
+		c.apiRoot(),
+		url.PathEscape(c.ProjectID),
+		url.PathEscape(c.BucketName),
+	)
+	for _, elem := range elems {
+		p += "/" + url.PathEscape(elem)
+	}
+	return p
+}
+
+// CreateMultipartUpload starts a multipart upload of an object stored under key.
+// Upload the parts with UploadPart or UploadPartsConcurrent, then call Complete,
+// or Abort to discard the parts uploaded so far.
+//
+// Example:
+//
+//	upload, err := client.CreateMultipartUpload("videos/recording.mp4")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := upload.UploadPartsConcurrent(parts, 4); err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := upload.Complete()
+func (c *Client) CreateMultipartUpload(key string) (*MultipartUpload, error) {
+	var result createUploadResponse
+	if err := c.doJSON("POST", c.uploadsPath(), nil, createUploadRequest{Key: key}, &result, "create upload"); err != nil {
+		return nil, err
+	}
+	if result.Key == "" {
+		result.Key = key
+	}
+
+	return &MultipartUpload{
+		Key:      result.Key,
+		UploadID: result.UploadID,
+		client:   c,
+		parts:    make(map[int]CompletedPart),
+	}, nil
+}
+
+// UploadPart uploads a single part and records its ETag for Complete. Uploading
+// the same part number again replaces the earlier part. Retries need to resend
+// the part, so they only happen when r is an io.ReadSeeker (bytes.Reader,
+// io.SectionReader, *os.File, ...); other readers are sent once.
+//
+// Example:
+//
+//	part, err := upload.UploadPart(1, bytes.NewReader(chunk))
+func (u *MultipartUpload) UploadPart(number int, r io.Reader) (CompletedPart, error) {
+	if number < 1 {
+		return CompletedPart{}, fmt.Errorf("invalid part number %d: must be at least 1", number)
+	}
+
+	c := u.client
+	partURL, err := c.presignURL("PUT", c.uploadsPath(u.UploadID, "parts", strconv.Itoa(number)), nil, DefaultOptions().ExpiresIn)
+	if err != nil {
+		return CompletedPart{}, err
+	}
+
+	// Create request
+	req, err := http.NewRequest("PUT", partURL, r)
+	if err != nil {
+		return CompletedPart{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	rewindable(req, r)
+
+	// Send request
+	resp, err := c.do("upload part", req)
+	if err != nil {
+		return CompletedPart{}, fmt.Errorf("failed to upload part %d: %w", number, err)
+	}
+	defer resp.Body.Close()
+
+	if !isSuccess(resp.StatusCode) {
+		return CompletedPart{}, newAPIError("upload part", resp)
+	}
+
+	part := CompletedPart{Number: number, ETag: resp.Header.Get("ETag")}
+	u.mu.Lock()
+	u.parts[number] = part
+	u.mu.Unlock()
+
+	return part, nil
+}
+
+// UploadPartsConcurrent uploads parts with at most concurrency uploads in
+// flight. Each part is retried according to the client's MaxRetries. If any
+// part still fails, remaining parts are skipped, the upload is aborted and the
+// first error is returned. On success call Complete to assemble the object.
+//
+// Example:
+//
+//	parts := []sdk.Part{
+//	    {Number: 1, Reader: bytes.NewReader(chunk1)},
+//	    {Number: 2, Reader: bytes.NewReader(chunk2)},
+//	}
+//	if err := upload.UploadPartsConcurrent(parts, 4); err != nil {
+//	    log.Fatal(err)
+//	}
+func (u *MultipartUpload) UploadPartsConcurrent(parts []Part, concurrency int) error {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		failed   = make(chan struct{})
+		sem      = make(chan struct{}, concurrency)
+	)
+
+loop:
+	for _, part := range parts {
+		select {
+		case <-failed:
+			break loop
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(part Part) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if _, err := u.UploadPart(part.Number, part.Reader); err != nil {
+				once.Do(func() {
+					firstErr = err
+					close(failed)
+				})
+			}
+		}(part)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		if err := u.Abort(); err != nil {
+			return fmt.Errorf("%w (abort failed: %v)", firstErr, err)
+		}
+		return firstErr
+	}
+	return nil
+}
+
+// CompletedParts returns the parts uploaded so far, ordered by part number.
+func (u *MultipartUpload) CompletedParts() []CompletedPart {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	parts := make([]CompletedPart, 0, len(u.parts))
+	for _, part := range u.parts {
+		parts = append(parts, part)
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Number < parts[j].Number })
+	return parts
+}
+
+// Complete assembles the uploaded parts, in part-number order, into the final
+// object and returns its details.
+//
+// Example:
+//
+//	resp, err := upload.Complete()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(resp.URL)
+func (u *MultipartUpload) Complete() (*FileResponse, error) {
+	parts := u.CompletedParts()
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("cannot complete upload %s without parts", u.UploadID)
+	}
+
+	var result FileResponse
+	c := u.client
+	if err := c.doJSON("POST", c.uploadsPath(u.UploadID, "complete"), nil, completeUploadRequest{Parts: parts}, &result, "complete upload"); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Abort cancels the upload and discards all parts uploaded so far.
+//
+// Example:
+//
+//	defer upload.Abort()
+func (u *MultipartUpload) Abort() error {
+	c := u.client
+	return c.doJSON("DELETE", c.uploadsPath(u.UploadID), nil, nil, nil, "abort upload")
+}
@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// multipartServer is a fake multipart upload endpoint. Parts listed in fail
+// are rejected with 500.
+type multipartServer struct {
+	t       *testing.T
+	mu      sync.Mutex
+	parts   map[string]string
+	puts    map[string]int
+	fail    map[string]bool
+	aborted bool
+	order   []CompletedPart
+}
+
+func newMultipartServer(t *testing.T) (*httptest.Server, *multipartServer) {
+	m := &multipartServer{t: t, parts: map[string]string{}, puts: map[string]int{}, fail: map[string]bool{}}
+	return httptest.NewServer(m), m
+}
+
+func (m *multipartServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	uploadsPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/uploads", testProjectID, testBucketName)
+	if r.URL.Query().Get("X-Mos-Signature") == "" {
+		m.t.Error("multipart requests should be presigned")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	case r.Method == "POST" && r.URL.Path == uploadsPath:
+		var body createUploadRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(createUploadResponse{UploadID: "up-1", Key: body.Key})
+	case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, uploadsPath+"/up-1/parts/"):
+		number := strings.TrimPrefix(r.URL.Path, uploadsPath+"/up-1/parts/")
+		m.puts[number]++
+		if m.fail[number] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		m.parts[number] = string(data)
+		w.Header().Set("ETag", `"etag-`+number+`"`)
+	case r.Method == "POST" && r.URL.Path == uploadsPath+"/up-1/complete":
+		var body completeUploadRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		m.order = body.Parts
+		var assembled string
+		for _, p := range body.Parts {
+			assembled += m.parts[fmt.Sprint(p.Number)]
+		}
+		json.NewEncoder(w).Encode(FileResponse{Name: "video.mp4", Size: int64(len(assembled))})
+	case r.Method == "DELETE" && r.URL.Path == uploadsPath+"/up-1":
+		m.aborted = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		m.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestMultipartUpload_Concurrent(t *testing.T) {
+	server, fake := newMultipartServer(t)
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	upload, err := client.CreateMultipartUpload("video.mp4")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload failed: %v", err)
+	}
+	if upload.UploadID != "up-1" || upload.Key != "video.mp4" {
+		t.Fatalf("unexpected upload %+v", upload)
+	}
+
+	var parts []Part
+	for i := 10; i >= 1; i-- {
+		parts = append(parts, Part{Number: i, Reader: strings.NewReader(fmt.Sprintf("part-%02d;", i))})
+	}
+	if err := upload.UploadPartsConcurrent(parts, 3); err != nil {
+		t.Fatalf("UploadPartsConcurrent failed: %v", err)
+	}
+
+	resp, err := upload.Complete()
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if resp.Size != 80 {
+		t.Errorf("expected 80 assembled bytes, got %d", resp.Size)
+	}
+
+	if len(fake.order) != 10 {
+		t.Fatalf("expected 10 parts in Complete, got %d", len(fake.order))
+	}
+	for i, p := range fake.order {
+		if p.Number != i+1 || p.ETag != fmt.Sprintf(`"etag-%d"`, i+1) {
+			t.Errorf("part %d out of order or missing ETag: %+v", i, p)
+		}
+	}
+}
+
+func TestMultipartUpload_AbortsOnFailure(t *testing.T) {
+	server, fake := newMultipartServer(t)
+	defer server.Close()
+	fake.fail["2"] = true
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.MaxRetries = 2
+	client.Backoff = BackoffFunc(func(int) time.Duration { return 0 })
+
+	upload, err := client.CreateMultipartUpload("video.mp4")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload failed: %v", err)
+	}
+
+	parts := []Part{
+		{Number: 1, Reader: bytes.NewReader([]byte("a"))},
+		{Number: 2, Reader: bytes.NewReader([]byte("b"))},
+		{Number: 3, Reader: bytes.NewReader([]byte("c"))},
+	}
+	if err := upload.UploadPartsConcurrent(parts, 1); err == nil {
+		t.Fatal("expected failure for part 2")
+	}
+	if !fake.aborted {
+		t.Error("failed upload should be aborted")
+	}
+}
+
+func TestMultipartUpload_InvalidPartNumber(t *testing.T) {
+	upload := &MultipartUpload{client: NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)}
+	if _, err := upload.UploadPart(0, strings.NewReader("x")); err == nil {
+		t.Error("part number 0 should be rejected")
+	}
+}
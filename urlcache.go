@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// URLCache is a size-bounded LRU cache of presigned URLs. When set as
+// Client.URLCache, GetObjectURL returns the cached URL for the same object and
+// lifetime until it is within the safety margin of its expiry, which keeps
+// links stable for downstream HTTP caches. It is safe for concurrent use.
+type URLCache struct {
+	size   int
+	margin time.Duration
+
+	mu      sync.Mutex
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+// urlCacheEntry is a cached URL and the time it stops being valid.
+type urlCacheEntry struct {
+	key     string
+	url     string
+	expires time.Time
+}
+
+// NewURLCache creates a cache holding at most size URLs. Cached URLs are
+// regenerated once less than margin of their validity remains.
+//
+// Example:
+//
+//	client.URLCache = sdk.NewURLCache(10000, 5*time.Minute)
+//	url := client.GetObjectURL("logo.png", time.Hour) // stable for ~55 minutes
+func NewURLCache(size int, margin time.Duration) *URLCache {
+	if size < 1 {
+		size = 1
+	}
+	return &URLCache{
+		size:    size,
+		margin:  margin,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Len returns the number of cached URLs.
+func (uc *URLCache) Len() int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return uc.order.Len()
+}
+
+// get returns the URL cached under key, or calls generate and caches its
+// result when there is none or the cached one is about to expire. expiresIn
+// is the lifetime generate signs the URL for.
+func (uc *URLCache) get(key string, expiresIn time.Duration, generate func() string) string {
+	now := time.Now()
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if elem, ok := uc.entries[key]; ok {
+		entry := elem.Value.(*urlCacheEntry)
+		if entry.expires.Sub(now) > uc.margin {
+			uc.order.MoveToFront(elem)
+			return entry.url
+		}
+		uc.order.Remove(elem)
+		delete(uc.entries, key)
+	}
+
+	// An empty URL means signing failed and is not worth keeping
+	url := generate()
+	if url == "" {
+		return url
+	}
+
+	// X-Mos-Expires has second precision, truncated like the signer does
+	entry := &urlCacheEntry{
+		key:     key,
+		url:     url,
+		expires: time.Unix(now.Add(expiresIn).Unix(), 0),
+	}
+	uc.entries[key] = uc.order.PushFront(entry)
+	if uc.order.Len() > uc.size {
+		oldest := uc.order.Back()
+		uc.order.Remove(oldest)
+		delete(uc.entries, oldest.Value.(*urlCacheEntry).key)
+	}
+	return entry.url
+}
@@ -0,0 +1,83 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestURLCache_ReusesURLs(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.URLCache = NewURLCache(10, time.Minute)
+
+	first := client.GetObjectURL("photo.jpg", time.Hour)
+	time.Sleep(1100 * time.Millisecond) // a new signature would carry a later X-Mos-Expires
+	if again := client.GetObjectURL("photo.jpg", time.Hour); again != first {
+		t.Errorf("expected cached URL %s, got %s", first, again)
+	}
+	if other := client.GetObjectURL("photo.jpg", 2*time.Hour); other == first {
+		t.Error("a different lifetime should not share the cached URL")
+	}
+	if client.URLCache.Len() != 2 {
+		t.Errorf("expected 2 cached URLs, got %d", client.URLCache.Len())
+	}
+}
+
+func TestURLCache_RefreshesNearExpiry(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	client.URLCache = NewURLCache(10, time.Hour)
+
+	// Every URL is within the margin, so none is reused
+	first := client.GetObjectURL("photo.jpg", 30*time.Minute)
+	time.Sleep(1100 * time.Millisecond)
+	if again := client.GetObjectURL("photo.jpg", 30*time.Minute); again == first {
+		t.Error("URL within the safety margin should be regenerated")
+	}
+}
+
+func TestURLCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewURLCache(2, 0)
+	calls := 0
+	get := func(key string) string {
+		return cache.get(key, time.Hour, func() string {
+			calls++
+			return key
+		})
+	}
+
+	get("a")
+	get("b")
+	get("a") // a is now most recently used
+	get("c") // evicts b
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+
+	calls = 0
+	get("a")
+	get("b")
+	if calls != 1 {
+		t.Errorf("expected only b to be regenerated, got %d generations", calls)
+	}
+}
+
+func TestURLCache_SharedBetweenClients(t *testing.T) {
+	cache := NewURLCache(10, time.Minute)
+	v1 := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	v1.URLCache = cache
+	v2 := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	v2.SignatureVersion = SignatureV2
+	v2.URLCache = cache
+	rotated := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, "rotated-secret")
+	rotated.URLCache = cache
+
+	v1.GetObjectURL("photo.jpg", time.Hour)
+	for _, client := range []*Client{v2, rotated} {
+		url := client.GetObjectURL("photo.jpg", time.Hour)
+		if err := client.VerifyPresignedURL("GET", url); err != nil {
+			t.Errorf("shared cache returned a URL signed with other settings: %v", err)
+		}
+	}
+	if cache.Len() != 3 {
+		t.Errorf("expected 3 cached URLs, got %d", cache.Len())
+	}
+}
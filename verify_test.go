@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGenerateSignatureWith(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	if client.GenerateSignatureWith(testSecretKey, "GET", "/path", 1735344000) != client.GenerateSignature("GET", "/path", 1735344000) {
+		t.Error("signing with the client's own secret should match GenerateSignature")
+	}
+	if client.GenerateSignatureWith("other-secret", "GET", "/path", 1735344000) == client.GenerateSignature("GET", "/path", 1735344000) {
+		t.Error("a different secret should produce a different signature")
+	}
+}
+
+func TestVerifyPresignedURL_Rotation(t *testing.T) {
+	old := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, "old-secret")
+	rotated := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, "new-secret")
+	rotated.PreviousSecretKey = "old-secret"
+
+	params := url.Values{"versionId": {"v1"}}
+	for name, u := range map[string]string{
+		"old secret": old.GeneratePresignedURLWithParams("GET", old.objectPath("a.jpg"), params, time.Hour),
+		"new secret": rotated.GeneratePresignedURLWithParams("GET", rotated.objectPath("a.jpg"), params, time.Hour),
+	} {
+		if err := rotated.VerifyPresignedURL("GET", u); err != nil {
+			t.Errorf("%s: URL should verify during rotation: %v", name, err)
+		}
+	}
+
+	// Once the previous secret is dropped, old URLs stop validating
+	rotated.PreviousSecretKey = ""
+	if err := rotated.VerifyPresignedURL("GET", old.GetObjectURL("a.jpg", time.Hour)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestVerifyPresignedURL_Rejects(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+	valid := client.GetObjectURL("a.jpg", time.Hour)
+
+	tampered, _ := url.Parse(valid)
+	query := tampered.Query()
+	query.Set("versionId", "injected")
+	tampered.RawQuery = query.Encode()
+
+	otherKey := NewClient(testBaseURL, testProjectID, testBucketName, "MOS_OTHER", testSecretKey)
+
+	tests := map[string]struct {
+		method, url string
+	}{
+		"wrong method":    {"DELETE", valid},
+		"expired":         {"GET", client.GetObjectURL("a.jpg", -time.Minute)},
+		"tampered query":  {"GET", tampered.String()},
+		"other key":       {"GET", otherKey.GetObjectURL("a.jpg", time.Hour)},
+		"missing expires": {"GET", testBaseURL + "/a.jpg?X-Mos-AccessKey=" + testAccessKey},
+	}
+	for name, tt := range tests {
+		if err := client.VerifyPresignedURL(tt.method, tt.url); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%s: expected ErrInvalidSignature, got %v", name, err)
+		}
+	}
+
+	if err := client.VerifyPresignedURL("GET", valid); err != nil {
+		t.Errorf("valid URL should verify: %v", err)
+	}
+}
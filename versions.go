@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ObjectVersion describes one historical version of an object in a versioned bucket.
+type ObjectVersion struct {
+	VersionID string `json:"version_id"`
+	Size      int64  `json:"size"`
+	ETag      string `json:"etag"`
+	IsLatest  bool   `json:"is_latest"`
+	CreatedAt string `json:"created_at"`
+}
+
+// versionParams returns the signed query parameters selecting versionID, or nil
+// to address the latest version.
+func versionParams(versionID string) url.Values {
+	if versionID == "" {
+		return nil
+	}
+	return url.Values{"versionId": {versionID}}
+}
+
+// versionsPath returns the API path of an object's version history.
+func (c *Client) versionsPath(filename string) string {
+	return fmt.Sprintf("%s/projects/%s/buckets/%s/versions/%s",
+		c.apiRoot(),
+		url.PathEscape(c.ProjectID),
+		url.PathEscape(c.BucketName),
+		escapeKey(filename),
+	)
+}
+
+// GetObjectVersionURL generates a presigned URL for downloading a specific version
+// of an object. The versionId parameter is signed, so it cannot be altered.
+// An empty versionID addresses the latest version, like GetObjectURL.
+//
+// Example:
+//
+//	url := client.GetObjectVersionURL("report.pdf", "3HL4kqtJlcpXroDTDmJ", time.Hour)
+func (c *Client) GetObjectVersionURL(filename, versionID string, expiresIn time.Duration) string {
+	return c.GeneratePresignedURLWithParams("GET", c.objectPath(filename), versionParams(versionID), expiresIn)
+}
+
+// ListObjectVersions returns the version history of an object, newest first.
+//
+// Example:
+//
+//	versions, err := client.ListObjectVersions("report.pdf")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, v := range versions {
+//	    fmt.Println(v.VersionID, v.CreatedAt, v.IsLatest)
+//	}
+func (c *Client) ListObjectVersions(filename string) ([]ObjectVersion, error) {
+	var result struct {
+		Versions []ObjectVersion `json:"versions"`
+	}
+	if err := c.doJSON("GET", c.versionsPath(filename), nil, nil, &result, "list versions"); err != nil {
+		return nil, err
+	}
+	return result.Versions, nil
+}
@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGetObjectVersionURL(t *testing.T) {
+	client := NewClient(testBaseURL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	versionURL := client.GetObjectVersionURL("report.pdf", "v2", time.Hour)
+
+	parsed, _ := url.Parse(versionURL)
+	query := parsed.Query()
+	if query.Get("versionId") != "v2" {
+		t.Errorf("URL should carry versionId: %s", versionURL)
+	}
+
+	var expires int64
+	fmt.Sscanf(query.Get("X-Mos-Expires"), "%d", &expires)
+	expected := client.GenerateSignatureWithParams("GET", parsed.EscapedPath(), expires, url.Values{"versionId": {"v2"}})
+	if query.Get("X-Mos-Signature") != expected {
+		t.Error("versionId should be part of the signature")
+	}
+
+	// Without a version the URL matches GetObjectURL
+	latest, _ := url.Parse(client.GetObjectVersionURL("report.pdf", "", time.Hour))
+	if latest.Query().Has("versionId") {
+		t.Error("empty version should address the latest version")
+	}
+}
+
+func TestVersionedDownloadAndDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("versionId") != "v1" {
+			t.Errorf("%s should target version v1, got %q", r.Method, r.URL.Query().Get("versionId"))
+		}
+		if r.URL.Query().Get("X-Mos-Signature") == "" {
+			t.Errorf("%s of a version should be presigned", r.Method)
+		}
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprint(w, "old content")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	data, err := client.DownloadBytes("report.pdf", &DownloadOptions{VersionID: "v1"})
+	if err != nil || string(data) != "old content" {
+		t.Errorf("versioned download failed: %q, %v", data, err)
+	}
+
+	if err := client.DeleteWithOptions("report.pdf", &DeleteOptions{VersionID: "v1"}); err != nil {
+		t.Errorf("versioned delete failed: %v", err)
+	}
+}
+
+func TestListObjectVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := fmt.Sprintf("/api/v1/projects/%s/buckets/%s/versions/report.pdf", testProjectID, testBucketName)
+		if r.Method != "GET" || r.URL.Path != expectedPath {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"versions":[{"version_id":"v2","is_latest":true},{"version_id":"v1","size":10}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testProjectID, testBucketName, testAccessKey, testSecretKey)
+
+	versions, err := client.ListObjectVersions("report.pdf")
+	if err != nil {
+		t.Fatalf("list should succeed: %v", err)
+	}
+	if len(versions) != 2 || versions[0].VersionID != "v2" || !versions[0].IsLatest || versions[1].Size != 10 {
+		t.Errorf("unexpected versions: %+v", versions)
+	}
+}